@@ -1,80 +1,481 @@
 package cmd
 
 import (
-	"PreFlight/core"
-	"PreFlight/modules"
-	"PreFlight/utils"
 	"context"
 	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/config"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/modules"
+	"github.com/MineHubs-Studios/PreFlight/utils"
 	"github.com/spf13/cobra"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
 	"strings"
+	"syscall"
 	"time"
 )
 
 var (
 	packageManagers string
 	timeoutSeconds  uint
+	reportFormat    string
+	reportOutput    string
+	reportTemplate  string
+	jobs            int
+	moduleTimeouts  map[string]string
+	commandTimeout  string
+	failOn          string
+	baselineFile    string
+	writeBaseline   string
+	configFile      string
+	sortModules     string
+	recursive       bool
+	excludeDirs     []string
+	watch           bool
+	noCache         bool
+	recordHistory   bool
+	againstFile     string
+	recordFile      string
+	replayFile      string
 )
 
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Checks if all required dependencies are installed",
-	Run: func(_ *cobra.Command, _ []string) {
-		// REGISTER ALL AVAILABLE MODULES.
-		availableModules := map[string]core.Module{
-			"php":      modules.PhpModule{},
-			"composer": modules.ComposerModule{},
-			"node":     modules.NodeModule{},
-			"package":  modules.PackageModule{},
-			"go":       modules.GoModule{},
+	Run: func(cmd *cobra.Command, _ []string) {
+		// PROPAGATE THE EXIT CODE SO preflight check CAN BE USED AS A CI GATE.
+		if exitCode := runCheck(cmd); exitCode != core.ExitSuccess {
+			os.Exit(exitCode)
 		}
+	},
+}
 
-		for name, module := range availableModules {
-			core.RegisterAvailableModule(name, module)
-		}
+// moduleAliases MAPS THE JavaScript PACKAGE MANAGERS TO THE package MODULE.
+var moduleAliases = map[string]string{
+	"npm":  "package",
+	"pnpm": "package",
+	"yarn": "package",
+	"bun":  "package",
+}
+
+// runCheck RUNS THE check COMMAND AND RETURNS ITS EXIT CODE.
+func runCheck(cmd *cobra.Command) (exitCode int) {
+	flags := cmd.Flags()
 
-		aliasMap := map[string]string{
-			"npm":  "package",
-			"pnpm": "package",
-			"yarn": "package",
-			"bun":  "package",
+	// START RECORDING OR REPLAYING BEFORE ANYTHING IS READ, SO THE CONFIGURATION IS PART OF THE SESSION.
+	if recordFile != "" || replayFile != "" {
+		// A RECURSIVE CHECK DISCOVERS PROJECTS, AND WATCH MODE WAITS FOR CHANGES, ON THIS MACHINE.
+		if (recordFile != "" && replayFile != "") || recursive || watch {
+			fmt.Println(utils.Red + "--record and --replay cannot be combined with each other, --recursive or --watch" + utils.Reset)
+			return core.ExitConfigError
 		}
 
-		// PROCESS REQUESTED MODULES.
-		var moduleNames []string
+		session, err := startSession()
+
+		if err != nil {
+			fmt.Printf(utils.Red+"Failed to start session: %v"+utils.Reset+"\n", err)
+			return core.ExitConfigError
+		}
 
-		if packageManagers != "" {
-			for _, name := range strings.Split(packageManagers, ",") {
-				normalized := strings.TrimSpace(strings.ToLower(name))
+		defer utils.SetSession(nil)
 
-				if alias, ok := aliasMap[normalized]; ok {
-					normalized = alias
+		if recordFile != "" {
+			defer func() {
+				if err := session.Write(recordFile); err != nil {
+					fmt.Printf(utils.Red+"Failed to write session: %v"+utils.Reset+"\n", err)
+					exitCode = core.ExitConfigError
 				}
+			}()
+		}
+	}
 
-				if normalized != "" {
-					moduleNames = append(moduleNames, normalized)
-				}
+	// LOAD THE PROJECT CONFIGURATION, CLI FLAGS OVERRIDE EVERY VALUE IT SETS.
+	projectConfig, err := config.LoadProjectConfig(projectDir, configFile)
+
+	if err != nil {
+		fmt.Printf(utils.Red+"Failed to load configuration: %v"+utils.Reset+"\n", err)
+		return core.ExitConfigError
+	}
+
+	if err := checkMinVersion(projectConfig.MinVersion); err != nil {
+		fmt.Printf(utils.Red+"%v"+utils.Reset+"\n", err)
+		return core.ExitConfigError
+	}
+
+	// LOAD THE ENVIRONMENT TO EVALUATE INSTEAD OF THIS MACHINE.
+	var environment *core.Environment
+
+	if againstFile != "" {
+		env, err := core.LoadEnvironment(againstFile)
+
+		if err != nil {
+			fmt.Printf(utils.Red+"Failed to load environment: %v"+utils.Reset+"\n", err)
+			return core.ExitConfigError
+		}
+
+		environment = &env
+	}
+
+	registry, err := newCheckRegistry(projectConfig, environment, requestedModules(projectConfig.Modules))
+
+	if err != nil {
+		fmt.Printf(utils.Red+"Failed to load configuration: %v"+utils.Reset+"\n", err)
+		return core.ExitConfigError
+	}
+
+	// PROCESS REQUESTED MODULES.
+	moduleNames, err := selectModules(registry, projectConfig.Modules)
+
+	if err != nil {
+		fmt.Printf(utils.Red+"Failed to register modules: %v"+utils.Reset+"\n", err)
+		return core.ExitConfigError
+	}
+
+	// TAKE THE TIMEOUT FROM THE FLAG OR THE CONFIGURATION.
+	timeout := time.Duration(timeoutSeconds) * time.Second
+
+	if !flags.Changed("timeout") && projectConfig.Timeouts.Total > 0 {
+		timeout = time.Duration(projectConfig.Timeouts.Total)
+	}
+
+	// PARSE PER-MODULE AND PER-COMMAND TIMEOUTS, FLAGS OVERRIDE THE CONFIGURED MODULES ONE BY ONE.
+	parsedModuleTimeouts := make(map[string]time.Duration, len(projectConfig.Timeouts.Modules)+len(moduleTimeouts))
+
+	for name, d := range projectConfig.Timeouts.Modules {
+		parsedModuleTimeouts[strings.ToLower(strings.TrimSpace(name))] = time.Duration(d)
+	}
+
+	for name, value := range moduleTimeouts {
+		d, err := utils.ParseTimeout(value)
+
+		if err != nil {
+			fmt.Printf(utils.Red+"Invalid --module-timeout for %s: %v"+utils.Reset+"\n", name, err)
+			return core.ExitConfigError
+		}
+
+		parsedModuleTimeouts[strings.ToLower(strings.TrimSpace(name))] = d
+	}
+
+	parsedCommandTimeout := time.Duration(projectConfig.Timeouts.Command)
+
+	if flags.Changed("command-timeout") {
+		if parsedCommandTimeout, err = utils.ParseTimeout(commandTimeout); err != nil {
+			fmt.Printf(utils.Red+"Invalid --command-timeout: %v"+utils.Reset+"\n", err)
+			return core.ExitConfigError
+		}
+	}
+
+	policy, err := core.ParseFailOn(flagOrConfig(cmd, "fail-on", failOn, projectConfig.FailOn))
+
+	if err != nil {
+		fmt.Printf(utils.Red+"Invalid --fail-on: %v"+utils.Reset+"\n", err)
+		return core.ExitConfigError
+	}
+
+	sortType, err := core.ParseSortType(flagOrConfig(cmd, "sort", sortModules, projectConfig.Sort))
+
+	if err != nil {
+		fmt.Printf(utils.Red+"Invalid --sort: %v"+utils.Reset+"\n", err)
+		return core.ExitConfigError
+	}
+
+	maxJobs := jobs
+
+	if !flags.Changed("jobs") && projectConfig.Jobs > 0 {
+		maxJobs = projectConfig.Jobs
+	}
+
+	// PICK THE NATIVE ANNOTATION FORMAT IN CI UNLESS THE USER CHOSE A FORMAT.
+	format, output := reportFormat, reportOutput
+
+	if !flags.Changed("format") {
+		if ciFormat := core.DetectCIFormat(); ciFormat != "" {
+			format = ciFormat
+
+			if format == core.FormatGitLabCodeQuality && output == "" {
+				output = core.GitLabCodeQualityFile
 			}
 		}
+	}
+
+	// A RECORDED RUN CHECKS EVERY MODULE, A MODULE SERVED FROM THE CACHE RUNS NO COMMAND TO CAPTURE.
+	// A REPLAYED RUN DOES NOT DESCRIBE THIS MACHINE, SO IT IS NOT RECORDED IN THE HISTORY.
+	// THE CACHE DESCRIBES THIS MACHINE TOO, SO A RUN AGAINST AN EXPORTED ENVIRONMENT NEITHER READS NOR WRITES IT.
+	useCache := !noCache && !projectConfig.NoCache && recordFile == "" && replayFile == "" && againstFile == ""
+	useHistory := (recordHistory || (!flags.Changed("history") && projectConfig.History)) && replayFile == ""
+
+	checkOptions := core.CheckOptions{
+		RunnerOptions: core.RunnerOptions{
+			Version:    Version,
+			ProjectDir: projectDir,
+			Modules:    moduleNames,
+			Timeout:    timeout,
+			Jobs:       maxJobs,
+
+			ModuleTimeouts: parsedModuleTimeouts,
+			CommandTimeout: parsedCommandTimeout,
+			FailOn:         policy,
+
+			Sort:        sortType,
+			ModuleOrder: projectConfig.Modules.Order,
+
+			Baseline:      flagOrConfig(cmd, "baseline", baselineFile, projectConfig.Baseline),
+			WriteBaseline: writeBaseline,
+			Cache:         useCache,
+			History:       useHistory,
+			Environment:   environment,
+		},
 
-		// REGISTER REQUESTED MODULES.
-		if err := core.RegisterModule(nil, moduleNames...); err != nil {
-			fmt.Printf(utils.Red+"Failed to register modules: %v\n", err)
-			return
+		Format:   format,
+		Output:   output,
+		Template: reportTemplate,
+	}
+
+	if watch {
+		// WATCH MODE DRAWS ITS OWN VIEW AND NEVER FINISHES WITH A REPORT.
+		if recursive || writeBaseline != "" || flags.Changed("format") || reportOutput != "" {
+			fmt.Println(utils.Red + "--watch cannot be combined with --recursive, --write-baseline, --format or --output" + utils.Reset)
+			return core.ExitConfigError
 		}
 
-		// SETUP CONTEXT WITH TIMEOUT FROM FLAG.
-		timeout := time.Duration(timeoutSeconds) * time.Second
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
-		defer cancel()
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		return core.RunWatch(ctx, registry, checkOptions.RunnerOptions)
+	}
 
+	if !recursive {
 		// RUN THE CHECKS.
-		core.RunChecks(ctx)
-	},
+		return core.RunChecks(context.Background(), registry, checkOptions)
+	}
+
+	// A BASELINE IS WRITTEN PER PROJECT, WHICH A RECURSIVE CHECK CANNOT DO IN A SINGLE FILE.
+	if writeBaseline != "" {
+		fmt.Println(utils.Red + "--write-baseline cannot be combined with --recursive" + utils.Reset)
+		return core.ExitConfigError
+	}
+
+	root := projectDir
+
+	if root == "" {
+		root = "."
+	}
+
+	projects, err := core.DiscoverProjects(root, append(projectConfig.Exclude, excludeDirs...))
+
+	if err != nil {
+		fmt.Printf(utils.Red+"Failed to discover projects: %v"+utils.Reset+"\n", err)
+		return core.ExitConfigError
+	}
+
+	// RUN THE CHECKS OF EVERY PROJECT.
+	return core.RunRecursiveChecks(context.Background(), registry, root, projects, checkOptions)
+}
+
+// newCheckRegistry REGISTERS THE BUILT-IN MODULES, THE TOOLS AND CUSTOM MODULES OF THE CONFIGURATION AND THE PLUGINS.
+// DESCRIBING A PLUGIN RUNS IT, SO PLUGINS ARE ONLY DESCRIBED WHEN requested IS EMPTY OR NAMES A MODULE THAT IS NOT BUILT-IN.
+// PLUGINS INSPECT THIS MACHINE THEMSELVES, SO THEY ARE NEVER RUN WHEN AN ENVIRONMENT IS EVALUATED INSTEAD.
+func newCheckRegistry(projectConfig config.ProjectConfig, environment *core.Environment, requested []string) (*core.Registry, error) {
+	registry := modules.NewRegistry()
+
+	if len(projectConfig.Tools) > 0 {
+		if err := registry.Register("tools", modules.ToolsModule{Constraints: projectConfig.Tools, File: projectConfig.Path}); err != nil {
+			return nil, err
+		}
+	}
+
+	// REGISTER THE MODULES DECLARED IN THE CONFIGURATION, THEY BEHAVE LIKE BUILT-IN ONES.
+	for _, definition := range projectConfig.CustomModules {
+		module, err := modules.NewCustomModule(definition, projectConfig.Path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.Register("", module); err != nil {
+			return nil, fmt.Errorf("custom module '%s' conflicts with an existing module", module.Name())
+		}
+	}
+
+	if environment != nil {
+		for _, plugin := range modules.DiscoverPlugins(searchedPluginDirs(projectConfig.PluginDirs)...) {
+			fmt.Fprintf(os.Stderr, utils.Yellow+"Skipping plugin %s, plugins cannot be evaluated against an environment"+utils.Reset+"\n", plugin.Path)
+		}
+
+		return registry, nil
+	}
+
+	if !selectsPlugins(registry, requested) {
+		return registry, nil
+	}
+
+	// REGISTER EXTERNAL PLUGINS, A PLUGIN NEVER REPLACES A BUILT-IN OR CUSTOM MODULE.
+	for _, module := range discoverPluginModules(projectConfig.PluginDirs) {
+		if err := registry.Register("", module); err != nil {
+			fmt.Fprintf(os.Stderr, utils.Yellow+"Skipping plugin %s, a module named '%s' already exists"+utils.Reset+"\n", module.Plugin().Path, module.Name())
+		}
+	}
+
+	return registry, nil
+}
+
+// startSession ACTIVATES THE SESSION OF --record OR --replay.
+// THE PROJECT DIRECTORY IS RECORDED AS AN ABSOLUTE PATH AND A REPLAYED SESSION ALWAYS CHECKS IT,
+// SO EVERY RECORDED PATH AND COMMAND MATCHES REGARDLESS OF WHERE THE SESSION IS REPLAYED.
+func startSession() (*utils.Session, error) {
+	if recordFile != "" {
+		dir, err := filepath.Abs(projectDir)
+
+		if err != nil {
+			return nil, err
+		}
+
+		projectDir = dir
+		session := utils.NewSession(projectDir, os.Args[1:])
+		utils.SetSession(session)
+
+		return session, nil
+	}
+
+	session, err := utils.LoadSession(replayFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	projectDir = session.ProjectDir
+	utils.SetSession(session)
+
+	fmt.Fprintf(os.Stderr, utils.Dim+"Replaying %s, recorded at %s on %s/%s: preflight %s"+utils.Reset+"\n",
+		replayFile, session.RecordedAt.Local().Format("02-01-2006 15:04:05"), session.OS, session.Arch, strings.Join(session.Args, " "))
+
+	return session, nil
+}
+
+// flagOrConfig RETURNS THE FLAG VALUE WHEN IT WAS SET ON THE COMMAND LINE OR NOTHING IS CONFIGURED.
+func flagOrConfig(cmd *cobra.Command, name, flagValue, configValue string) string {
+	if cmd.Flags().Changed(name) || configValue == "" {
+		return flagValue
+	}
+
+	return configValue
+}
+
+// selectsPlugins REPORTS WHETHER A RUN OF THE requested MODULES COULD SELECT A PLUGIN, WHICH IS THE CASE WHEN IT REQUESTS
+// EVERY MODULE OR A MODULE THAT IS NOT REGISTERED YET. A PLUGIN MAY DESCRIBE ITSELF WITH ANY NAME, SO IT IS NOT MATCHED.
+func selectsPlugins(registry *core.Registry, requested []string) bool {
+	if len(requested) == 0 {
+		return true
+	}
+
+	registered := registry.Names()
+
+	for _, name := range requested {
+		if !slices.Contains(registered, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// requestedModules RETURNS THE MODULES A CHECK IS LIMITED TO BY --pm OR THE enable LIST, EMPTY WHEN IT CHECKS EVERY MODULE.
+func requestedModules(modulesConfig config.ModulesConfig) []string {
+	if packageManagers != "" {
+		return normalizeModuleNames(strings.Split(packageManagers, ","))
+	}
+
+	return normalizeModuleNames(modulesConfig.Enable)
+}
+
+// selectModules RETURNS THE MODULES TO REGISTER, --pm OVERRIDES THE enable AND disable LISTS OF THE CONFIGURATION.
+func selectModules(registry *core.Registry, modulesConfig config.ModulesConfig) ([]string, error) {
+	if packageManagers != "" {
+		return normalizeModuleNames(strings.Split(packageManagers, ",")), nil
+	}
+
+	moduleNames := normalizeModuleNames(modulesConfig.Enable)
+
+	if len(modulesConfig.Disable) == 0 {
+		return moduleNames, nil
+	}
+
+	// DISABLING WITHOUT AN ENABLE LIST STARTS FROM ALL AVAILABLE MODULES.
+	if len(moduleNames) == 0 {
+		moduleNames = registry.Names()
+	}
+
+	disabled := make(map[string]struct{}, len(modulesConfig.Disable))
+
+	for _, name := range normalizeModuleNames(modulesConfig.Disable) {
+		disabled[name] = struct{}{}
+	}
+
+	selected := make([]string, 0, len(moduleNames))
+
+	for _, name := range moduleNames {
+		if _, ok := disabled[name]; !ok {
+			selected = append(selected, name)
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("every module is disabled by the configuration")
+	}
+
+	return selected, nil
+}
+
+// normalizeModuleNames LOWERCASES MODULE NAMES, RESOLVES ALIASES AND DROPS DUPLICATES.
+func normalizeModuleNames(names []string) []string {
+	var normalizedNames []string
+
+	seen := make(map[string]struct{}, len(names))
+
+	for _, name := range names {
+		normalized := strings.TrimSpace(strings.ToLower(name))
+
+		if alias, ok := moduleAliases[normalized]; ok {
+			normalized = alias
+		}
+
+		if _, ok := seen[normalized]; ok || normalized == "" {
+			continue
+		}
+
+		seen[normalized] = struct{}{}
+		normalizedNames = append(normalizedNames, normalized)
+	}
+
+	return normalizedNames
+}
+
+// checkMinVersion FAILS WHEN THE PROJECT REQUIRES A NEWER PreFlight, DEVELOPMENT BUILDS ARE ALWAYS ALLOWED.
+func checkMinVersion(minVersion string) error {
+	installed := strings.TrimPrefix(Version, "v")
+
+	if minVersion == "" || installed == "" || installed[0] < '0' || installed[0] > '9' {
+		return nil
+	}
+
+	if !utils.MatchVersionConstraint(installed, ">="+strings.TrimPrefix(strings.TrimSpace(minVersion), "v")) {
+		return fmt.Errorf("this project requires PreFlight %s or newer, but %s is installed", minVersion, Version)
+	}
+
+	return nil
 }
 
 func init() {
 	// DEFINE FLAGS FOR CHECK COMMAND.
+	checkCmd.Flags().StringVar(
+		&configFile,
+		"config",
+		"",
+		"Project configuration file (default .preflight.yml, .preflight.json or .preflight.toml)",
+	)
+
 	checkCmd.Flags().StringVar(
 		&packageManagers,
 		"pm",
@@ -89,5 +490,139 @@ func init() {
 		"Timeout in seconds for all checks to complete",
 	)
 
+	checkCmd.Flags().StringToStringVar(
+		&moduleTimeouts,
+		"module-timeout",
+		nil,
+		"Timeout per module, e.g. composer=60,go=1m30s (use * for all other modules)",
+	)
+
+	checkCmd.Flags().StringVar(
+		&commandTimeout,
+		"command-timeout",
+		"",
+		"Timeout for every external command, in seconds or as a duration (e.g. 30s)",
+	)
+
+	checkCmd.Flags().IntVarP(
+		&jobs,
+		"jobs",
+		"j",
+		0,
+		"Maximum number of modules to check concurrently (0 = all at once)",
+	)
+
+	checkCmd.Flags().StringVar(
+		&failOn,
+		"fail-on",
+		string(core.FailOnError),
+		"Lowest severity that makes the check fail (error,warning,never)",
+	)
+
+	checkCmd.Flags().StringVar(
+		&sortModules,
+		"sort",
+		string(core.SortByPriority),
+		"Order of the reported modules (priority,name)",
+	)
+
+	checkCmd.Flags().StringVar(
+		&baselineFile,
+		"baseline",
+		"",
+		"Hide the known findings listed in this baseline file",
+	)
+
+	checkCmd.Flags().StringVar(
+		&writeBaseline,
+		"write-baseline",
+		"",
+		"Write all current errors and warnings to this baseline file",
+	)
+
+	checkCmd.Flags().BoolVarP(
+		&recursive,
+		"recursive",
+		"r",
+		false,
+		"Check every project below the directory that contains a composer.json, package.json or go.mod",
+	)
+
+	checkCmd.Flags().StringSliceVar(
+		&excludeDirs,
+		"exclude",
+		nil,
+		"Comma-separated .gitignore-style patterns of directories skipped by --recursive",
+	)
+
+	checkCmd.Flags().BoolVarP(
+		&watch,
+		"watch",
+		"w",
+		false,
+		"Re-run the affected modules whenever a manifest, lock file, node_modules or vendor changes (Linux only)",
+	)
+
+	checkCmd.Flags().BoolVar(
+		&noCache,
+		"no-cache",
+		false,
+		"Run every module instead of serving unchanged modules from "+core.CacheDir,
+	)
+
+	checkCmd.Flags().BoolVar(
+		&recordHistory,
+		"history",
+		false,
+		"Record this run in "+core.HistoryDir+", see preflight history",
+	)
+
+	checkCmd.Flags().StringVar(
+		&againstFile,
+		"against",
+		"",
+		"Evaluate the checks against an environment written by preflight env export instead of this machine",
+	)
+
+	checkCmd.Flags().StringVar(
+		&recordFile,
+		"record",
+		"",
+		"Record every command and file read of this run to a session file, for a reproducible bug report",
+	)
+
+	checkCmd.Flags().StringVar(
+		&replayFile,
+		"replay",
+		"",
+		"Re-run the checks purely from a session file written by --record",
+	)
+
+	// ALLOW --baseline AND --write-baseline WITHOUT A VALUE.
+	checkCmd.Flags().Lookup("baseline").NoOptDefVal = core.DefaultBaselineFile
+	checkCmd.Flags().Lookup("write-baseline").NoOptDefVal = core.DefaultBaselineFile
+
+	checkCmd.Flags().StringVar(
+		&reportFormat,
+		"format",
+		core.FormatText,
+		"Report format ("+strings.Join(core.ReportFormats(), ",")+")",
+	)
+
+	checkCmd.Flags().StringVarP(
+		&reportOutput,
+		"output",
+		"o",
+		"",
+		"Write the report to a file instead of stdout",
+	)
+
+	checkCmd.Flags().StringVar(
+		&reportTemplate,
+		"template",
+		"",
+		"Go text/template file used by --format=template",
+	)
+
 	rootCmd.AddCommand(checkCmd)
 }
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/config"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/modules"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var (
+	envOutput     string
+	envDiffFormat string
+	envDiffOutput string
+)
+
+// envCmd GROUPS THE COMMANDS THAT SNAPSHOT AND COMPARE THE ENVIRONMENT OF A MACHINE.
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Export and compare environment snapshots",
+	Long: `Exports a snapshot of the tool versions, PHP build, PHP extensions and installed dependencies of this machine,
+and compares snapshots of different machines, such as two laptops or a laptop and a CI image.`,
+	Example: "preflight env export -o laptop.json",
+}
+
+// envExportCmd REPRESENTS THE env export COMMAND THAT WRITES THE ENVIRONMENT OF THIS MACHINE.
+var envExportCmd = &cobra.Command{
+	Use:     "export",
+	Short:   "Write a JSON snapshot of the environment of this machine",
+	Long:    `Writes every detected tool version, the PHP build and extensions, the installed Composer, npm and Go dependencies, the OS and the arch as JSON.`,
+	Example: "preflight env export --output=env.json",
+	Args:    cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		projectConfig, err := config.LoadProjectConfig(projectDir, configFile)
+
+		if err != nil {
+			fmt.Printf(utils.Red+"Failed to load configuration: %v"+utils.Reset+"\n", err)
+			os.Exit(core.ExitConfigError)
+		}
+
+		env, err := modules.ExportEnvironment(projectContext(), Version, projectConfig)
+
+		if err != nil {
+			fmt.Printf(utils.Red+"Failed to export environment: %v"+utils.Reset+"\n", err)
+			os.Exit(core.ExitConfigError)
+		}
+
+		if err := core.WriteEnvironment(env, envOutput); err != nil {
+			fmt.Printf(utils.Red+"Failed to write environment: %v"+utils.Reset+"\n", err)
+			os.Exit(core.ExitConfigError)
+		}
+	},
+}
+
+// envDiffCmd REPRESENTS THE env diff COMMAND THAT SHOWS WHAT DIFFERS BETWEEN TWO ENVIRONMENTS.
+var envDiffCmd = &cobra.Command{
+	Use:   "diff <a.json> <b.json>",
+	Short: "Show what differs between two environment snapshots",
+	Long: `Shows every tool version, PHP build value, PHP extension and installed dependency that differs between two
+snapshots written by preflight env export. Exits with 1 when the environments differ.`,
+	Example: "preflight env diff laptop.json ci.json",
+	Args:    cobra.ExactArgs(2),
+	Run: func(_ *cobra.Command, args []string) {
+		environments := make([]core.Environment, len(args))
+
+		for i, path := range args {
+			env, err := core.LoadEnvironment(path)
+
+			if err != nil {
+				fmt.Printf(utils.Red+"Failed to load environment: %v"+utils.Reset+"\n", err)
+				os.Exit(core.ExitConfigError)
+			}
+
+			environments[i] = env
+		}
+
+		differences := core.DiffEnvironments(environments[0], environments[1])
+
+		if err := core.WriteEnvironmentDiff(differences, core.EnvironmentDiffOptions{
+			Format: envDiffFormat,
+			Output: envDiffOutput,
+			A:      args[0],
+			B:      args[1],
+		}); err != nil {
+			fmt.Printf(utils.Red+"Failed to show diff: %v"+utils.Reset+"\n", err)
+			os.Exit(core.ExitConfigError)
+		}
+
+		if len(differences) > 0 {
+			os.Exit(core.ExitRequirementsFailed)
+		}
+	},
+}
+
+func init() {
+	envExportCmd.Flags().StringVarP(
+		&envOutput,
+		"output",
+		"o",
+		"",
+		"Write the environment to a file instead of stdout",
+	)
+
+	envExportCmd.Flags().StringVar(
+		&configFile,
+		"config",
+		"",
+		"Project configuration file whose tools and custom modules are exported too",
+	)
+
+	envDiffCmd.Flags().StringVar(
+		&envDiffFormat,
+		"format",
+		core.FormatText,
+		"Output format (text,json)",
+	)
+
+	envDiffCmd.Flags().StringVarP(
+		&envDiffOutput,
+		"output",
+		"o",
+		"",
+		"Write the diff to a file instead of stdout",
+	)
+
+	envCmd.AddCommand(envExportCmd, envDiffCmd)
+	rootCmd.AddCommand(envCmd)
+}
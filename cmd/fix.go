@@ -1,8 +1,7 @@
 package cmd
 
 import (
-	"PreFlight/core"
-	"context"
+	"github.com/MineHubs-Studios/PreFlight/modules"
 	"github.com/spf13/cobra"
 )
 
@@ -12,8 +11,9 @@ var fixCmd = &cobra.Command{
 	Use:   "fix",
 	Short: "Fix missing dependencies (Composer & npm)",
 	Run: func(_ *cobra.Command, _ []string) {
-		ctx := context.Background()
-		core.FixDependencies(ctx, forceFix)
+		ctx := projectContext()
+		modules.FixDependencies(ctx, forceFix)
+		modules.FixPlugins(ctx, forceFix, discoverPluginModules(projectPluginDirs()))
 	},
 }
 
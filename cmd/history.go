@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var (
+	historyFormat string
+	historyOutput string
+	historyLimit  int
+)
+
+// historyCmd REPRESENTS THE HISTORY COMMAND THAT SHOWS HOW THE ENVIRONMENT CHANGED OVER THE RECORDED RUNS.
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show how requirements and module durations changed over the recorded runs",
+	Long: `Shows the runs recorded by preflight check --history, when each requirement was introduced,
+started or stopped failing, which manifest change introduced it, and how module durations changed.`,
+	Example: "preflight history --limit=10",
+	Run: func(_ *cobra.Command, _ []string) {
+		entries, err := core.LoadHistory(projectDir)
+
+		if err != nil {
+			fmt.Printf(utils.Red+"Failed to load history: %v"+utils.Reset+"\n", err)
+			os.Exit(core.ExitConfigError)
+		}
+
+		if err := core.WriteHistory(core.AnalyzeHistory(entries), core.HistoryOptions{
+			Format: historyFormat,
+			Output: historyOutput,
+			Limit:  historyLimit,
+		}); err != nil {
+			fmt.Printf(utils.Red+"Failed to show history: %v"+utils.Reset+"\n", err)
+			os.Exit(core.ExitConfigError)
+		}
+	},
+}
+
+func init() {
+	historyCmd.Flags().StringVar(
+		&historyFormat,
+		"format",
+		core.FormatText,
+		"Output format (text,json)",
+	)
+
+	historyCmd.Flags().StringVarP(
+		&historyOutput,
+		"output",
+		"o",
+		"",
+		"Write the history to a file instead of stdout",
+	)
+
+	historyCmd.Flags().IntVar(
+		&historyLimit,
+		"limit",
+		20,
+		"Number of most recent runs and requirement changes to show (0 = all)",
+	)
+
+	rootCmd.AddCommand(historyCmd)
+}
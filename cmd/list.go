@@ -1,12 +1,22 @@
 package cmd
 
 import (
-	"PreFlight/core"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/modules"
+	"github.com/MineHubs-Studios/PreFlight/utils"
 	"github.com/spf13/cobra"
+	"os"
+	"slices"
 	"strings"
 )
 
-var listPackageManagers string
+var (
+	listPackageManagers string
+	listFormat          string
+	listOutput          string
+	listTemplate        string
+)
 
 // listCmd REPRESENTS THE LIST COMMAND THAT DISPLAYS DEPENDENCIES.
 var listCmd = &cobra.Command{
@@ -37,11 +47,37 @@ var listCmd = &cobra.Command{
 			}
 		}
 
-		dependencies := core.GetAllDependencies(selectedPMs...)
-		core.PrintDependencies(dependencies)
+		if err := core.WriteDependencies(listDependencies(selectedPMs), core.ListOptions{
+			Format:   listFormat,
+			Output:   listOutput,
+			Template: listTemplate,
+		}); err != nil {
+			fmt.Printf(utils.Red+"Failed to list dependencies: %v"+utils.Reset+"\n", err)
+			os.Exit(core.ExitConfigError)
+		}
 	},
 }
 
+// listDependencies COLLECTS THE DEPENDENCIES OF THE SELECTED PACKAGE MANAGERS AND OF THE PLUGINS THAT SUPPORT list,
+// ALL OF THEM WHEN NONE ARE SELECTED.
+func listDependencies(selectedPMs []string) core.DependencyResult {
+	dependencies := core.GetAllDependencies(projectDir, selectedPMs...)
+
+	for _, plugin := range discoverPluginModules(projectPluginDirs()) {
+		name := strings.ToLower(plugin.Name())
+
+		if !plugin.Supports(modules.PluginList) || (len(selectedPMs) > 0 && !slices.Contains(selectedPMs, name)) {
+			continue
+		}
+
+		if deps, err := plugin.Dependencies(projectContext()); err == nil && len(deps) > 0 {
+			dependencies.Dependencies[name] = deps
+		}
+	}
+
+	return dependencies
+}
+
 func init() {
 	listCmd.Flags().StringVar(
 		&listPackageManagers,
@@ -50,5 +86,27 @@ func init() {
 		"Comma-separated list of package managers to list (composer,package,go,npm,yarn,pnpm,bun)",
 	)
 
+	listCmd.Flags().StringVar(
+		&listFormat,
+		"format",
+		core.FormatText,
+		"Output format ("+strings.Join(core.DependencyFormats(), ",")+")",
+	)
+
+	listCmd.Flags().StringVarP(
+		&listOutput,
+		"output",
+		"o",
+		"",
+		"Write the list to a file instead of stdout",
+	)
+
+	listCmd.Flags().StringVar(
+		&listTemplate,
+		"template",
+		"",
+		"Go text/template file used by --format=template",
+	)
+
 	rootCmd.AddCommand(listCmd)
 }
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/config"
+	"github.com/MineHubs-Studios/PreFlight/modules"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	pluginDirs        []string
+	pluginTestWithFix bool
+)
+
+// pluginCmd GROUPS THE COMMANDS FOR EXTERNAL preflight-<name> PLUGINS.
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Inspect and verify external preflight-<name> plugins",
+}
+
+// pluginListCmd SHOWS ALL DISCOVERED PLUGINS.
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List discovered plugins",
+	Run: func(_ *cobra.Command, _ []string) {
+		ow := utils.NewOutputWriter()
+		dirs := projectPluginDirs()
+		plugins := discoverPluginModules(dirs)
+
+		if len(plugins) == 0 {
+			ow.Println(utils.Yellow + "No plugins found in " + strings.Join(searchedPluginDirs(dirs), ", ") + " or PATH." + utils.Reset)
+			return
+		}
+
+		for _, plugin := range plugins {
+			description, err := plugin.Description()
+
+			if err != nil {
+				ow.Printf("%s %s%s%s %s\n      %s%v%s\n", utils.Red+utils.CrossMark+utils.Reset, utils.Bold, plugin.Name(), utils.Reset, plugin.Plugin().Path, utils.Red, err, utils.Reset)
+				continue
+			}
+
+			ow.Printf("%s %s%s%s %s\n", utils.Green+utils.CheckMark+utils.Reset, utils.Bold, strings.TrimSpace(plugin.Name()+" "+description.Version), utils.Reset, utils.Dim+plugin.Plugin().Path+utils.Reset)
+
+			if description.Description != "" {
+				ow.Printf("      %s\n", description.Description)
+			}
+
+			ow.Printf("      %sCommands: %s%s\n", utils.Dim, strings.Join(description.Commands, ", "), utils.Reset)
+		}
+	},
+}
+
+// pluginTestCmd RUNS THE CONFORMANCE HARNESS AGAINST A PLUGIN.
+var pluginTestCmd = &cobra.Command{
+	Use:     "test <name|path>",
+	Short:   "Verify that a plugin implements the plugin protocol",
+	Example: "preflight plugin test docker\npreflight plugin test ./bin/preflight-docker",
+	Args:    cobra.ExactArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		plugin, err := findPlugin(args[0])
+
+		if err != nil {
+			fmt.Printf(utils.Red+"%v"+utils.Reset+"\n", err)
+			os.Exit(1)
+		}
+
+		ow := utils.NewOutputWriter()
+		ow.Println(utils.Bold + "Testing plugin " + plugin.Path + utils.Reset)
+
+		failed := 0
+
+		for _, result := range modules.RunPluginConformance(projectContext(), plugin, pluginTestWithFix) {
+			if result.Passed {
+				ow.Printf("  %s %s\n", utils.Green+utils.CheckMark+utils.Reset, result.Name)
+				continue
+			}
+
+			failed++
+			ow.Printf("  %s %s\n      %s%s%s\n", utils.Red+utils.CrossMark+utils.Reset, result.Name, utils.Red, result.Detail, utils.Reset)
+		}
+
+		if failed > 0 {
+			ow.Printf("\n%s%d conformance checks failed.%s\n", utils.Red, failed, utils.Reset)
+			os.Exit(1)
+		}
+
+		ow.Println("\n" + utils.Green + "The plugin conforms to protocol version " + fmt.Sprint(modules.PluginProtocolVersion) + "." + utils.Reset)
+	},
+}
+
+// searchedPluginDirs RETURNS THE PLUGIN DIRECTORIES SEARCHED BEFORE PATH.
+func searchedPluginDirs(dirs []string) []string {
+	if len(dirs) == 0 {
+		return []string{filepath.Join(projectDir, modules.DefaultPluginDir)}
+	}
+
+	return dirs
+}
+
+// projectPluginDirs RETURNS THE --plugin-dir FLAG, OR THE PLUGIN DIRECTORIES OF THE PROJECT CONFIGURATION.
+func projectPluginDirs() []string {
+	if len(pluginDirs) > 0 {
+		return pluginDirs
+	}
+
+	projectConfig, err := config.LoadProjectConfig(projectDir, "")
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, utils.Yellow+"Ignoring the project configuration: %v"+utils.Reset+"\n", err)
+		return nil
+	}
+
+	return projectConfig.PluginDirs
+}
+
+// discoverPluginModules DISCOVERS AND DESCRIBES ALL PLUGINS.
+func discoverPluginModules(dirs []string) []modules.PluginModule {
+	plugins := modules.DiscoverPlugins(searchedPluginDirs(dirs)...)
+	pluginModules := make([]modules.PluginModule, 0, len(plugins))
+
+	for _, plugin := range plugins {
+		pluginModules = append(pluginModules, modules.NewPluginModule(projectContext(), plugin))
+	}
+
+	return pluginModules
+}
+
+// findPlugin RESOLVES A PLUGIN BY PATH OR BY NAME.
+func findPlugin(nameOrPath string) (modules.Plugin, error) {
+	if strings.ContainsRune(nameOrPath, filepath.Separator) || strings.ContainsRune(nameOrPath, '/') {
+		path, err := filepath.Abs(nameOrPath)
+
+		if err != nil {
+			return modules.Plugin{}, err
+		}
+
+		if _, err := os.Stat(path); err != nil {
+			return modules.Plugin{}, fmt.Errorf("plugin %s not found", nameOrPath)
+		}
+
+		name := strings.TrimPrefix(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), modules.PluginPrefix)
+
+		return modules.Plugin{Name: name, Path: path}, nil
+	}
+
+	name := strings.ToLower(strings.TrimPrefix(nameOrPath, modules.PluginPrefix))
+	dirs := searchedPluginDirs(projectPluginDirs())
+
+	for _, plugin := range modules.DiscoverPlugins(dirs...) {
+		if plugin.Name == name {
+			return plugin, nil
+		}
+	}
+
+	return modules.Plugin{}, fmt.Errorf("plugin %s%s not found in %s or PATH", modules.PluginPrefix, name, strings.Join(dirs, ", "))
+}
+
+func init() {
+	pluginCmd.PersistentFlags().StringSliceVar(
+		&pluginDirs,
+		"plugin-dir",
+		nil,
+		"Directories searched for plugins before PATH (default "+modules.DefaultPluginDir+")",
+	)
+
+	pluginTestCmd.Flags().BoolVar(
+		&pluginTestWithFix,
+		"fix",
+		false,
+		"Also call fix, which may modify the project",
+	)
+
+	pluginCmd.AddCommand(pluginListCmd, pluginTestCmd)
+	rootCmd.AddCommand(pluginCmd)
+}
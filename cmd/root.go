@@ -1,11 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/utils"
 	"github.com/spf13/cobra"
 	"os"
 )
 
+// projectDir IS THE PROJECT EVERY COMMAND RUNS AGAINST, THE WORKING DIRECTORY IS USED WHEN EMPTY.
+var projectDir string
+
 // rootCmd REPRESENTS THE BASE COMMAND WHEN THE PROGRAM IS EXECUTED WITHOUT SUBCOMMANDS.
 var rootCmd = &cobra.Command{
 	Use:   "PreFlight",
@@ -18,6 +24,36 @@ It supports multiple package managers such as composer, npm, pnpm and yarn.`,
 	Version:       fmt.Sprintf("%s (built: %s)", "1.0.0", "Unknown"),
 	SilenceErrors: true,
 	SilenceUsage:  true,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		if err := validateProjectDir(projectDir); err != nil {
+			fmt.Printf(utils.Red+"Invalid --dir: %v"+utils.Reset+"\n", err)
+			os.Exit(core.ExitConfigError)
+		}
+	},
+}
+
+// validateProjectDir FAILS WHEN THE --dir FLAG DOES NOT POINT TO A DIRECTORY.
+func validateProjectDir(dir string) error {
+	if dir == "" {
+		return nil
+	}
+
+	info, err := os.Stat(dir)
+
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+
+	return nil
+}
+
+// projectContext RETURNS A BACKGROUND CONTEXT CARRYING THE PROJECT DIRECTORY OF --dir.
+func projectContext() context.Context {
+	return utils.WithProjectDir(context.Background(), projectDir)
 }
 
 // Execute ADDS ALL CHILD COMMANDS TO THE ROOT COMMAND AND SETS FLAGS APPROPRIATELY.
@@ -39,4 +75,11 @@ func Execute() error {
 func init() {
 	// ENABLE SHELL COMPLETION.
 	rootCmd.CompletionOptions.DisableDefaultCmd = false
+
+	rootCmd.PersistentFlags().StringVar(
+		&projectDir,
+		"dir",
+		"",
+		"Project directory to run against (default the working directory)",
+	)
 }
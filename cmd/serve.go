@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/config"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"github.com/spf13/cobra"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var (
+	serveListen         string
+	serveToken          string
+	serveAllowedOrigins []string
+)
+
+// serveCmd REPRESENTS THE SERVE COMMAND THAT EXPOSES CHECKS AND DEPENDENCIES OVER A LOCAL HTTP API.
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve checks, their results and live progress over a local HTTP API",
+	Long: `Starts an HTTP server that triggers checks, returns the JSON report of the latest check, lists the dependencies
+of the project and streams the progress of every module as Server-Sent Events, for dashboards and editors.
+Checks use the project configuration. The server only listens on localhost unless --listen says otherwise,
+which requires a token.`,
+	Example: "preflight serve --listen 127.0.0.1:7878 --token=secret",
+	Args:    cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		projectConfig, err := config.LoadProjectConfig(projectDir, configFile)
+
+		if err != nil {
+			fmt.Printf(utils.Red+"Failed to load configuration: %v"+utils.Reset+"\n", err)
+			os.Exit(core.ExitConfigError)
+		}
+
+		if err := checkMinVersion(projectConfig.MinVersion); err != nil {
+			fmt.Printf(utils.Red+"%v"+utils.Reset+"\n", err)
+			os.Exit(core.ExitConfigError)
+		}
+
+		// A SERVED CHECK MAY REQUEST ANY MODULE, SO EVERY PLUGIN IS DESCRIBED.
+		registry, err := newCheckRegistry(projectConfig, nil, nil)
+
+		if err != nil {
+			fmt.Printf(utils.Red+"Failed to load configuration: %v"+utils.Reset+"\n", err)
+			os.Exit(core.ExitConfigError)
+		}
+
+		// A CHECK THAT REQUESTS NO MODULES CHECKS THE MODULES OF THE CONFIGURATION.
+		configuredModules, err := selectModules(registry, projectConfig.Modules)
+
+		if err != nil {
+			fmt.Printf(utils.Red+"Failed to register modules: %v"+utils.Reset+"\n", err)
+			os.Exit(core.ExitConfigError)
+		}
+
+		runnerOptions, err := serveRunnerOptions(projectConfig)
+
+		if err != nil {
+			fmt.Printf(utils.Red+"Failed to load configuration: %v"+utils.Reset+"\n", err)
+			os.Exit(core.ExitConfigError)
+		}
+
+		token := serveToken
+
+		if token == "" {
+			token = os.Getenv("PREFLIGHT_TOKEN")
+		}
+
+		if token == "" && len(serveAllowedOrigins) > 0 {
+			fmt.Println(utils.Red + "--allow-origin requires --token, a page of another origin must not run checks without one" + utils.Reset)
+			os.Exit(core.ExitConfigError)
+		}
+
+		listener, err := net.Listen("tcp", serveListen)
+
+		if err != nil {
+			fmt.Printf(utils.Red+"Failed to listen: %v"+utils.Reset+"\n", err)
+			os.Exit(core.ExitConfigError)
+		}
+
+		// CHECKS RUN EXTERNAL COMMANDS, SO THE SERVER IS NEVER REACHABLE FROM THE NETWORK WITHOUT A TOKEN.
+		if addr, ok := listener.Addr().(*net.TCPAddr); ok && !addr.IP.IsLoopback() && token == "" {
+			_ = listener.Close()
+			fmt.Printf(utils.Red+"Refusing to listen on %s without a token, set --token or $PREFLIGHT_TOKEN"+utils.Reset+"\n", listener.Addr())
+			os.Exit(core.ExitConfigError)
+		}
+
+		server := core.NewServer(registry, core.ServerOptions{
+			Runner: runnerOptions,
+			Modules: func(requested []string) []string {
+				if len(requested) == 0 {
+					return configuredModules
+				}
+
+				return normalizeModuleNames(requested)
+			},
+			Dependencies: func(managers []string) core.DependencyResult {
+				return listDependencies(normalizeModuleNames(managers))
+			},
+			Token:          token,
+			AllowedOrigins: serveAllowedOrigins,
+		})
+
+		fmt.Printf(utils.Bold+"Serving PreFlight on http://%s"+utils.Reset+"\n", listener.Addr())
+		fmt.Println(utils.Dim + "  POST /api/checks         trigger a check, ?modules=php,go limits it" + utils.Reset)
+		fmt.Println(utils.Dim + "  GET  /api/checks/latest  JSON report of the latest check" + utils.Reset)
+		fmt.Println(utils.Dim + "  GET  /api/dependencies   dependencies, ?pm=composer,go limits them" + utils.Reset)
+		fmt.Println(utils.Dim + "  GET  /api/events         progress of running checks as Server-Sent Events" + utils.Reset)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		if err := server.Serve(ctx, listener); err != nil {
+			fmt.Printf(utils.Red+"Failed to serve: %v"+utils.Reset+"\n", err)
+			os.Exit(core.ExitConfigError)
+		}
+	},
+}
+
+// serveRunnerOptions RETURNS THE OPTIONS OF EVERY SERVED CHECK, WHICH ARE TAKEN FROM THE PROJECT CONFIGURATION.
+func serveRunnerOptions(projectConfig config.ProjectConfig) (core.RunnerOptions, error) {
+	policy, err := core.ParseFailOn(projectConfig.FailOn)
+
+	if err != nil {
+		return core.RunnerOptions{}, fmt.Errorf("invalid fail-on: %w", err)
+	}
+
+	sortType, err := core.ParseSortType(projectConfig.Sort)
+
+	if err != nil {
+		return core.RunnerOptions{}, fmt.Errorf("invalid sort: %w", err)
+	}
+
+	// A SERVED CHECK IS LIMITED LIKE preflight check WITHOUT --timeout.
+	timeout := 300 * time.Second
+
+	if projectConfig.Timeouts.Total > 0 {
+		timeout = time.Duration(projectConfig.Timeouts.Total)
+	}
+
+	moduleTimeouts := make(map[string]time.Duration, len(projectConfig.Timeouts.Modules))
+
+	for name, d := range projectConfig.Timeouts.Modules {
+		moduleTimeouts[strings.ToLower(strings.TrimSpace(name))] = time.Duration(d)
+	}
+
+	return core.RunnerOptions{
+		Version:    Version,
+		ProjectDir: projectDir,
+		Timeout:    timeout,
+		Jobs:       projectConfig.Jobs,
+
+		ModuleTimeouts: moduleTimeouts,
+		CommandTimeout: time.Duration(projectConfig.Timeouts.Command),
+		FailOn:         policy,
+
+		Sort:        sortType,
+		ModuleOrder: projectConfig.Modules.Order,
+
+		Baseline: projectConfig.Baseline,
+		Cache:    !noCache && !projectConfig.NoCache,
+		History:  projectConfig.History,
+	}, nil
+}
+
+func init() {
+	serveCmd.Flags().StringVar(
+		&serveListen,
+		"listen",
+		"127.0.0.1:7878",
+		"Address to listen on, a non-loopback address requires --token",
+	)
+
+	serveCmd.Flags().StringVar(
+		&serveToken,
+		"token",
+		"",
+		"Shared secret every request must send as a bearer token, defaults to $PREFLIGHT_TOKEN",
+	)
+
+	serveCmd.Flags().StringSliceVar(
+		&serveAllowedOrigins,
+		"allow-origin",
+		nil,
+		"Origin of a web page allowed to call the API, e.g. https://dashboard.example.com, requires --token",
+	)
+
+	serveCmd.Flags().BoolVar(
+		&noCache,
+		"no-cache",
+		false,
+		"Run every module instead of serving unchanged modules from "+core.CacheDir,
+	)
+
+	serveCmd.Flags().StringVar(
+		&configFile,
+		"config",
+		"",
+		"Project configuration file (default .preflight.yml, .preflight.json or .preflight.toml)",
+	)
+
+	rootCmd.AddCommand(serveCmd)
+}
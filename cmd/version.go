@@ -1,8 +1,8 @@
 package cmd
 
 import (
-	"PreFlight/core"
-	"PreFlight/utils"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/utils"
 	"github.com/spf13/cobra"
 	"runtime"
 )
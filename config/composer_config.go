@@ -1,10 +1,11 @@
 package config
 
 import (
-	"PreFlight/utils"
 	"encoding/json"
 	"fmt"
-	"os"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -24,18 +25,19 @@ type ComposerConfig struct {
 	Error           error
 }
 
-// LoadComposerConfig PARSES composer.json, composer.lock, AND RETURNS ComposerConfig.
-func LoadComposerConfig() ComposerConfig {
+// LoadComposerConfig PARSES composer.json, composer.lock OF THE PROJECT IN projectDir AND RETURNS ComposerConfig.
+func LoadComposerConfig(projectDir string) ComposerConfig {
 	composerConfig := ComposerConfig{}
-	composerConfig.PackageManager = utils.DetectPackageManager("composer")
+	composerConfig.PackageManager = utils.DetectPackageManager(projectDir, "composer")
+	path := filepath.Join(projectDir, "composer.json")
 
-	if _, err := os.Stat("composer.json"); err == nil {
+	if _, err := utils.Stat(path); err == nil {
 		composerConfig.HasJSON = true
 	} else {
 		return composerConfig
 	}
 
-	file, err := os.ReadFile("composer.json")
+	file, err := utils.ReadFile(path) //nolint:gosec
 
 	if err != nil {
 		composerConfig.Error = fmt.Errorf("unable to read composer.json: %w", err)
@@ -69,5 +71,9 @@ func LoadComposerConfig() ComposerConfig {
 		composerConfig.DevDependencies = append(composerConfig.DevDependencies, devDep)
 	}
 
+	sort.Strings(composerConfig.Dependencies)
+	sort.Strings(composerConfig.PHPExtensions)
+	sort.Strings(composerConfig.DevDependencies)
+
 	return composerConfig
 }
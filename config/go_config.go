@@ -1,9 +1,9 @@
 package config
 
 import (
-	"PreFlight/utils"
 	"fmt"
-	"os"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"path/filepath"
 	"strings"
 )
 
@@ -15,9 +15,10 @@ type GoConfig struct {
 	Error          error
 }
 
-func LoadGoConfig() GoConfig {
+// LoadGoConfig PARSES go.mod OF THE PROJECT IN projectDir AND RETURNS GoConfig.
+func LoadGoConfig(projectDir string) GoConfig {
 	var goConfig GoConfig
-	goConfig.PackageManager = utils.DetectPackageManager("go")
+	goConfig.PackageManager = utils.DetectPackageManager(projectDir, "go")
 
 	if goConfig.PackageManager.LockFile == "" {
 		goConfig.HasMod = false
@@ -26,7 +27,7 @@ func LoadGoConfig() GoConfig {
 
 	goConfig.HasMod = true
 
-	data, err := os.ReadFile("go.mod")
+	data, err := utils.ReadFile(filepath.Join(projectDir, "go.mod")) //nolint:gosec
 
 	if err != nil {
 		goConfig.Error = fmt.Errorf("could not read go.mod: %w", err)
@@ -1,10 +1,11 @@
 package config
 
 import (
-	"PreFlight/utils"
 	"encoding/json"
 	"fmt"
-	"os"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -31,18 +32,19 @@ type PackageConfig struct {
 	Error           error
 }
 
-// LoadPackageConfig PARSES package.json, LOCK FILES AND RETURNS PackageConfig.
-func LoadPackageConfig() PackageConfig {
+// LoadPackageConfig PARSES package.json, LOCK FILES OF THE PROJECT IN projectDir AND RETURNS PackageConfig.
+func LoadPackageConfig(projectDir string) PackageConfig {
 	packageConfig := PackageConfig{}
-	packageConfig.PackageManager = utils.DetectPackageManager("package")
+	packageConfig.PackageManager = utils.DetectPackageManager(projectDir, "package")
+	path := filepath.Join(projectDir, "package.json")
 
-	if _, err := os.Stat("package.json"); err == nil {
+	if _, err := utils.Stat(path); err == nil {
 		packageConfig.HasJSON = true
 	} else {
 		return packageConfig
 	}
 
-	file, err := os.ReadFile("package.json")
+	file, err := utils.ReadFile(path) //nolint:gosec
 
 	if err != nil {
 		packageConfig.Error = fmt.Errorf("unable to read package.json: %w", err)
@@ -73,5 +75,8 @@ func LoadPackageConfig() PackageConfig {
 		packageConfig.DevDependencies = append(packageConfig.DevDependencies, devDep)
 	}
 
+	sort.Strings(packageConfig.Dependencies)
+	sort.Strings(packageConfig.DevDependencies)
+
 	return packageConfig
 }
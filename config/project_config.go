@@ -0,0 +1,188 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"gopkg.in/yaml.v3"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ProjectConfigFiles ARE THE FILE NAMES SEARCHED FOR THE PROJECT CONFIGURATION, IN ORDER.
+var ProjectConfigFiles = []string{".preflight.yml", ".preflight.yaml", ".preflight.json", ".preflight.toml"}
+
+// ProjectConfig IS THE CHECKED-IN CONFIGURATION OF preflight check, CLI FLAGS OVERRIDE EVERY VALUE.
+type ProjectConfig struct {
+	// Path OF THE LOADED FILE, EMPTY WHEN THE PROJECT HAS NO CONFIGURATION.
+	Path string `json:"-"`
+
+	// MinVersion IS THE LOWEST PreFlight VERSION ALLOWED TO CHECK THE PROJECT.
+	MinVersion string `json:"minVersion"`
+
+	Modules  ModulesConfig  `json:"modules"`
+	Timeouts TimeoutsConfig `json:"timeouts"`
+
+	// Sort IS THE SORT TYPE OF THE REPORT, priority OR name.
+	Sort string `json:"sort"`
+
+	// Tools MAPS A TOOL NAME TO AN EXTRA VERSION CONSTRAINT, E.G. composer: "^2.5".
+	Tools map[string]string `json:"tools"`
+
+	// CustomModules DECLARE MODULES THAT CHECK ADDITIONAL TOOLS WITHOUT WRITING Go CODE.
+	CustomModules []CustomModuleConfig `json:"customModules"`
+
+	// PluginDirs ARE SEARCHED FOR preflight-<name> PLUGINS BEFORE PATH, DEFAULTS TO .preflight/plugins.
+	PluginDirs []string `json:"pluginDirs"`
+
+	// Exclude LISTS .gitignore-STYLE PATTERNS OF DIRECTORIES THAT preflight check --recursive SKIPS.
+	Exclude []string `json:"exclude"`
+
+	FailOn   string `json:"failOn"`
+	Jobs     int    `json:"jobs"`
+	Baseline string `json:"baseline"`
+
+	// History RECORDS EVERY RUN IN .preflight/history, LIKE --history.
+	History bool `json:"history"`
+
+	// NoCache RUNS EVERY MODULE INSTEAD OF SERVING UNCHANGED MODULES FROM .preflight/cache, LIKE --no-cache.
+	NoCache bool `json:"noCache"`
+}
+
+// ModulesConfig SELECTS AND ORDERS THE MODULES OF A PROJECT.
+type ModulesConfig struct {
+	// Enable LISTS THE ONLY MODULES TO RUN, ALL MODULES RUN WHEN EMPTY.
+	Enable []string `json:"enable"`
+
+	// Disable LISTS MODULES THAT NEVER RUN.
+	Disable []string `json:"disable"`
+
+	// Order LISTS MODULES THAT ARE REPORTED FIRST, IN THIS ORDER.
+	Order []string `json:"order"`
+}
+
+// CustomModuleConfig DECLARES A MODULE THAT CHECKS THE VERSION OF A SINGLE TOOL.
+type CustomModuleConfig struct {
+	// Name OF THE MODULE AS USED BY --pm AND IN REPORTS, DEFAULTS TO Binary.
+	Name string `json:"name"`
+
+	// Binary IS THE EXECUTABLE THAT MUST BE AVAILABLE IN PATH.
+	Binary string `json:"binary"`
+
+	// VersionCommand PRINTS THE VERSION, E.G. "docker --version", DEFAULTS TO "<binary> --version".
+	VersionCommand string `json:"versionCommand"`
+
+	// VersionRegex EXTRACTS THE VERSION FROM THE OUTPUT, THE FIRST CAPTURE GROUP IS USED WHEN PRESENT.
+	VersionRegex string `json:"versionRegex"`
+
+	// Constraint IS CHECKED WITH utils.ValidateVersion, ANY VERSION IS ACCEPTED WHEN EMPTY.
+	Constraint string `json:"constraint"`
+
+	// EOL LISTS END-OF-LIFE VERSION PREFIXES, E.G. "19.03".
+	EOL []string `json:"eol"`
+
+	// Remediation REPLACES THE DEFAULT REMEDIATION OF EVERY FAILED FINDING.
+	Remediation string `json:"remediation"`
+}
+
+// TimeoutsConfig HOLDS THE TIMEOUTS OF A PROJECT, GIVEN IN SECONDS OR AS DURATIONS.
+type TimeoutsConfig struct {
+	Total   Duration            `json:"total"`
+	Command Duration            `json:"command"`
+	Modules map[string]Duration `json:"modules"`
+}
+
+// Duration ACCEPTS A NUMBER OF SECONDS OR A DURATION STRING LIKE 1m30s.
+type Duration time.Duration
+
+// UnmarshalJSON PARSES A DURATION FROM A NUMBER OR A STRING.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	parsed, err := utils.ParseTimeout(value)
+
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(parsed)
+
+	return nil
+}
+
+// LoadProjectConfig READS THE GIVEN CONFIGURATION FILE, OR THE FIRST OF ProjectConfigFiles IN projectDir WHEN PATH IS EMPTY.
+// A PROJECT WITHOUT A CONFIGURATION FILE RESULTS IN AN EMPTY CONFIGURATION.
+// RELATIVE PATHS IN THE CONFIGURATION ARE RESOLVED AGAINST projectDir.
+func LoadProjectConfig(projectDir, path string) (ProjectConfig, error) {
+	var projectConfig ProjectConfig
+
+	if path == "" {
+		for _, name := range ProjectConfigFiles {
+			if _, err := utils.Stat(filepath.Join(projectDir, name)); err == nil {
+				path = filepath.Join(projectDir, name)
+				break
+			}
+		}
+
+		if path == "" {
+			return projectConfig, nil
+		}
+	}
+
+	data, err := utils.ReadFile(path) //nolint:gosec
+
+	if err != nil {
+		return projectConfig, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	// EVERY FORMAT IS NORMALIZED TO JSON, SO ALL OF THEM SHARE ONE SCHEMA AND REJECT UNKNOWN KEYS ALIKE.
+	var raw map[string]any
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	case ".json":
+		err = json.Unmarshal(data, &raw)
+	default:
+		return projectConfig, fmt.Errorf("unsupported configuration format %s, use .yml, .json or .toml", path)
+	}
+
+	if err != nil {
+		return projectConfig, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	normalized, err := json.Marshal(raw)
+
+	if err != nil {
+		return projectConfig, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(normalized))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&projectConfig); err != nil {
+		return projectConfig, fmt.Errorf("invalid %s: %w", path, err)
+	}
+
+	projectConfig.Path = path
+	projectConfig.Baseline = projectPath(projectDir, projectConfig.Baseline)
+
+	for i, dir := range projectConfig.PluginDirs {
+		projectConfig.PluginDirs[i] = projectPath(projectDir, dir)
+	}
+
+	return projectConfig, nil
+}
+
+// projectPath RESOLVES A RELATIVE PATH OF THE CONFIGURATION AGAINST THE PROJECT DIRECTORY.
+func projectPath(projectDir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(projectDir, path)
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDurationUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    time.Duration
+		wantErr bool
+	}{
+		{data: `90`, want: 90 * time.Second},
+		{data: `"90"`, want: 90 * time.Second},
+		{data: `"1m30s"`, want: 90 * time.Second},
+		{data: `"500ms"`, want: 500 * time.Millisecond},
+		{data: `""`, want: 0},
+		{data: `-5`, wantErr: true},
+		{data: `"soon"`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		var d Duration
+		err := d.UnmarshalJSON([]byte(test.data))
+
+		if (err != nil) != test.wantErr {
+			t.Errorf("UnmarshalJSON(%s) error = %v, wantErr %v", test.data, err, test.wantErr)
+			continue
+		}
+
+		if time.Duration(d) != test.want {
+			t.Errorf("UnmarshalJSON(%s) = %v, want %v", test.data, time.Duration(d), test.want)
+		}
+	}
+}
+
+func TestLoadProjectConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr bool
+	}{
+		{
+			name: "yaml",
+			file: ".preflight.yml",
+			content: `modules:
+  enable: [php, composer]
+timeouts:
+  total: 90
+  command: 1m30s
+  modules:
+    composer: 30s
+failOn: warning
+baseline: baseline.json
+`,
+		},
+		{
+			name: "toml",
+			file: ".preflight.toml",
+			content: `failOn = "warning"
+baseline = "baseline.json"
+
+[modules]
+enable = ["php", "composer"]
+
+[timeouts]
+total = 90
+command = "1m30s"
+
+[timeouts.modules]
+composer = "30s"
+`,
+		},
+		{
+			name: "json",
+			file: ".preflight.json",
+			content: `{"modules": {"enable": ["php", "composer"]}, "failOn": "warning", "baseline": "baseline.json",
+"timeouts": {"total": 90, "command": "1m30s", "modules": {"composer": "30s"}}}`,
+		},
+		{
+			name:    "unknown key",
+			file:    ".preflight.yml",
+			content: "failOn: warning\nfialOn: error\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown nested key",
+			file:    ".preflight.toml",
+			content: "[modules]\nenabled = [\"php\"]\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid duration",
+			file:    ".preflight.json",
+			content: `{"timeouts": {"total": "soon"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "unsupported format",
+			file:    ".preflight.ini",
+			content: "failOn = warning\n",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, test.file)
+
+			if err := os.WriteFile(path, []byte(test.content), 0o644); err != nil { //nolint:gosec
+				t.Fatal(err)
+			}
+
+			projectConfig, err := LoadProjectConfig(dir, path)
+
+			if (err != nil) != test.wantErr {
+				t.Fatalf("LoadProjectConfig() error = %v, wantErr %v", err, test.wantErr)
+			}
+
+			if test.wantErr {
+				return
+			}
+
+			if len(projectConfig.Modules.Enable) != 2 || projectConfig.Modules.Enable[1] != "composer" {
+				t.Errorf("Modules.Enable = %v, want [php composer]", projectConfig.Modules.Enable)
+			}
+
+			if got := time.Duration(projectConfig.Timeouts.Total); got != 90*time.Second {
+				t.Errorf("Timeouts.Total = %v, want 1m30s", got)
+			}
+
+			if got := time.Duration(projectConfig.Timeouts.Command); got != 90*time.Second {
+				t.Errorf("Timeouts.Command = %v, want 1m30s", got)
+			}
+
+			if got := time.Duration(projectConfig.Timeouts.Modules["composer"]); got != 30*time.Second {
+				t.Errorf("Timeouts.Modules[composer] = %v, want 30s", got)
+			}
+
+			if projectConfig.FailOn != "warning" {
+				t.Errorf("FailOn = %q, want warning", projectConfig.FailOn)
+			}
+
+			// RELATIVE PATHS ARE RESOLVED AGAINST THE PROJECT DIRECTORY.
+			if want := filepath.Join(dir, "baseline.json"); projectConfig.Baseline != want {
+				t.Errorf("Baseline = %q, want %q", projectConfig.Baseline, want)
+			}
+
+			if projectConfig.Path != path {
+				t.Errorf("Path = %q, want %q", projectConfig.Path, path)
+			}
+		})
+	}
+}
+
+func TestLoadProjectConfigWithoutFile(t *testing.T) {
+	projectConfig, err := LoadProjectConfig(t.TempDir(), "")
+
+	if err != nil {
+		t.Fatalf("LoadProjectConfig() error = %v", err)
+	}
+
+	if projectConfig.Path != "" {
+		t.Errorf("Path = %q, want empty", projectConfig.Path)
+	}
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"os"
+	"sort"
+	"strings"
+)
+
+// DefaultBaselineFile IS USED WHEN --baseline OR --write-baseline IS GIVEN WITHOUT A PATH.
+const DefaultBaselineFile = ".preflight-baseline.json"
+
+// BaselineVersion IS INCREMENTED WHENEVER THE BASELINE FILE CHANGES IN AN INCOMPATIBLE WAY.
+const BaselineVersion = 1
+
+// Baseline LISTS THE ACCEPTED FINDINGS OF A PROJECT.
+type Baseline struct {
+	Version  int             `json:"version"`
+	Findings []BaselineEntry `json:"findings"`
+}
+
+// BaselineEntry IDENTIFIES AN ACCEPTED FINDING BY RULE ID AND SUBJECT, SO REWORDED MESSAGES STILL MATCH.
+type BaselineEntry struct {
+	RuleID  string `json:"ruleId"`
+	Subject string `json:"subject,omitempty"`
+}
+
+// NewBaseline CREATES A BASELINE ACCEPTING EVERY ERROR AND WARNING OF THE REPORT.
+func NewBaseline(report CheckReport) Baseline {
+	baseline := Baseline{Version: BaselineVersion, Findings: make([]BaselineEntry, 0)}
+	seen := make(map[BaselineEntry]struct{})
+
+	for _, result := range report.Results {
+		for _, finding := range result.Findings {
+			if !isBaselinable(finding) {
+				continue
+			}
+
+			entry := BaselineEntry{RuleID: finding.RuleID, Subject: finding.Subject}
+
+			if _, ok := seen[entry]; ok {
+				continue
+			}
+
+			seen[entry] = struct{}{}
+			baseline.Findings = append(baseline.Findings, entry)
+		}
+	}
+
+	sort.Slice(baseline.Findings, func(i, j int) bool {
+		a, b := baseline.Findings[i], baseline.Findings[j]
+
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+
+		return a.Subject < b.Subject
+	})
+
+	return baseline
+}
+
+// LoadBaseline READS A BASELINE FILE WRITTEN BY --write-baseline.
+func LoadBaseline(path string) (Baseline, error) {
+	var baseline Baseline
+
+	data, err := utils.ReadFile(path) //nolint:gosec
+
+	if err != nil {
+		return baseline, fmt.Errorf("unable to read baseline: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return baseline, fmt.Errorf("unable to parse baseline %s: %w", path, err)
+	}
+
+	if baseline.Version > BaselineVersion {
+		return baseline, fmt.Errorf("baseline %s has version %d, this PreFlight supports up to %d", path, baseline.Version, BaselineVersion)
+	}
+
+	return baseline, nil
+}
+
+// Write STORES THE BASELINE AS INDENTED JSON.
+func (b Baseline) Write(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("unable to write baseline: %w", err)
+	}
+
+	return nil
+}
+
+// Contains REPORTS WHETHER THE FINDING IS ACCEPTED BY THE BASELINE.
+func (b Baseline) Contains(finding Finding) bool {
+	if !isBaselinable(finding) {
+		return false
+	}
+
+	for _, entry := range b.Findings {
+		if entry.RuleID == finding.RuleID && entry.Subject == finding.Subject {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Apply MOVES THE FINDINGS ACCEPTED BY THE BASELINE OUT OF EACH RESULT, SO ONLY NEW FINDINGS AFFECT THE STATUS.
+func (b Baseline) Apply(report *CheckReport) {
+	for i := range report.Results {
+		b.applyResult(&report.Results[i])
+	}
+}
+
+// applyResult MOVES THE FINDINGS ACCEPTED BY THE BASELINE OUT OF A SINGLE RESULT.
+func (b Baseline) applyResult(result *CheckResult) {
+	findings := make([]Finding, 0, len(result.Findings))
+
+	for _, finding := range result.Findings {
+		if b.Contains(finding) {
+			result.Baselined = append(result.Baselined, finding)
+			continue
+		}
+
+		findings = append(findings, finding)
+	}
+
+	result.Findings = findings
+}
+
+// isBaselinable REPORTS WHETHER A FINDING CAN BE ACCEPTED, SUCCESSES AND RUN STATES LIKE TIMEOUTS NEVER ARE.
+func isBaselinable(finding Finding) bool {
+	return finding.Severity != SeveritySuccess && !strings.HasPrefix(finding.RuleID, "preflight/")
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBaselineContains(t *testing.T) {
+	baseline := Baseline{Version: BaselineVersion, Findings: []BaselineEntry{
+		{RuleID: RulePHPExtension, Subject: "ext-intl"},
+		{RuleID: RuleGoEOL},
+		{RuleID: RuleModuleTimeout, Subject: "Composer"},
+	}}
+
+	tests := []struct {
+		name    string
+		finding Finding
+		want    bool
+	}{
+		{
+			name:    "same rule and subject",
+			finding: Finding{Severity: SeverityError, RuleID: RulePHPExtension, Subject: "ext-intl", Message: "Missing extension intl."},
+			want:    true,
+		},
+		{
+			name:    "reworded message",
+			finding: Finding{Severity: SeverityWarning, RuleID: RulePHPExtension, Subject: "ext-intl", Message: "Something else."},
+			want:    true,
+		},
+		{
+			name:    "other subject",
+			finding: Finding{Severity: SeverityError, RuleID: RulePHPExtension, Subject: "ext-gd"},
+		},
+		{
+			name:    "other rule",
+			finding: Finding{Severity: SeverityError, RuleID: RulePHPExtensionDeprecated, Subject: "ext-intl"},
+		},
+		{
+			name:    "rule without subject",
+			finding: Finding{Severity: SeverityWarning, RuleID: RuleGoEOL},
+			want:    true,
+		},
+		{
+			name:    "subject not in the baseline",
+			finding: Finding{Severity: SeverityWarning, RuleID: RuleGoEOL, Subject: "go"},
+		},
+		{
+			name:    "success",
+			finding: Finding{Severity: SeveritySuccess, RuleID: RulePHPExtension, Subject: "ext-intl"},
+		},
+		{
+			name:    "run state",
+			finding: Finding{Severity: SeverityWarning, RuleID: RuleModuleTimeout, Subject: "Composer"},
+		},
+	}
+
+	for _, test := range tests {
+		if got := baseline.Contains(test.finding); got != test.want {
+			t.Errorf("%s: Contains() = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestBaselineRoundTrip(t *testing.T) {
+	report := CheckReport{Results: []CheckResult{
+		{Scope: "PHP", Findings: []Finding{
+			{Severity: SeverityError, RuleID: RulePHPExtension, Subject: "ext-intl"},
+			{Severity: SeveritySuccess, RuleID: RulePHPVersion, Subject: "php"},
+		}},
+		{Scope: "Composer", Findings: []Finding{
+			{Severity: SeverityWarning, RuleID: RuleComposerDependency, Subject: "laravel/framework"},
+			{Severity: SeverityWarning, RuleID: RuleComposerDependency, Subject: "laravel/framework"},
+		}},
+		blockedResult(fakeModule{name: "Package"}, []string{"Node"}),
+	}}
+
+	path := filepath.Join(t.TempDir(), DefaultBaselineFile)
+
+	if err := NewBaseline(report).Write(path); err != nil {
+		t.Fatal(err)
+	}
+
+	baseline, err := LoadBaseline(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []BaselineEntry{
+		{RuleID: RuleComposerDependency, Subject: "laravel/framework"},
+		{RuleID: RulePHPExtension, Subject: "ext-intl"},
+	}
+
+	if len(baseline.Findings) != len(want) {
+		t.Fatalf("Findings = %v, want %v", baseline.Findings, want)
+	}
+
+	for i := range want {
+		if baseline.Findings[i] != want[i] {
+			t.Errorf("Findings[%d] = %v, want %v", i, baseline.Findings[i], want[i])
+		}
+	}
+
+	baseline.Apply(&report)
+
+	if got := report.Baselined(); got != 3 {
+		t.Errorf("Baselined() = %d, want 3", got)
+	}
+
+	// ONLY THE BLOCKED MODULE STILL FAILS THE RUN.
+	if errors, warnings, _ := report.Totals(); errors != 1 || warnings != 0 {
+		t.Errorf("Totals() = %d errors, %d warnings, want 1 error", errors, warnings)
+	}
+}
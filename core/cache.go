@@ -0,0 +1,252 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// CacheDir IS WHERE THE RUNNER STORES THE FINDINGS OF CACHEABLE MODULES, RELATIVE TO THE PROJECT ROOT.
+const CacheDir = ".preflight/cache"
+
+// cacheVersion IS INCREMENTED WHENEVER THE FORMAT OF A CACHE ENTRY CHANGES, OLDER ENTRIES ARE IGNORED.
+const cacheVersion = 1
+
+// CacheInputs DESCRIBE EVERYTHING THE FINDINGS OF A MODULE DEPEND ON.
+type CacheInputs struct {
+	// Files ARE FINGERPRINTED BY THEIR CONTENT, DIRECTORIES BY THEIR MODIFICATION TIME.
+	// RELATIVE PATHS ARE RESOLVED AGAINST THE PROJECT ROOT.
+	Files []string
+
+	// Tools ARE LOOKED UP IN PATH AND FINGERPRINTED BY THEIR PATH, SIZE AND MODIFICATION TIME.
+	Tools []string
+
+	// Settings ARE ANY OTHER VALUES THE FINDINGS DEPEND ON, SUCH AS CONFIGURED VERSION CONSTRAINTS.
+	Settings []string
+}
+
+// CacheableModule IS IMPLEMENTED BY MODULES WHOSE FINDINGS ONLY CHANGE WHEN ONE OF THEIR CacheInputs CHANGES.
+// WHEN CACHING IS ENABLED, SUCH A MODULE IS ONLY RUN WHEN ITS INPUTS CHANGED SINCE THE LAST RUN.
+type CacheableModule interface {
+	CacheInputs(ctx context.Context) CacheInputs
+}
+
+// cacheEntry IS THE CACHED RESULT OF A SINGLE MODULE.
+type cacheEntry struct {
+	Version  int       `json:"version"`
+	Key      string    `json:"key"`
+	Findings []Finding `json:"findings"`
+}
+
+// checkModule RUNS A MODULE, OR SERVES ITS FINDINGS FROM THE CACHE WHEN NONE OF ITS INPUTS CHANGED.
+// IT REPORTS WHETHER THE FINDINGS CAME FROM THE CACHE.
+func checkModule(ctx context.Context, module Module, options RunnerOptions) ([]Finding, bool) {
+	cacheable, ok := module.(CacheableModule)
+
+	if !options.Cache || !ok {
+		return module.CheckRequirements(ctx), false
+	}
+
+	key := cacheKey(ctx, module.Name(), options.Version, cacheable.CacheInputs(ctx))
+	path := utils.ProjectPath(ctx, filepath.Join(CacheDir, strings.ToLower(module.Name())+".cache.json"))
+
+	if findings, ok := readCacheEntry(path, key); ok {
+		return findings, true
+	}
+
+	findings := module.CheckRequirements(ctx)
+
+	// FINDINGS OF AN INTERRUPTED MODULE ARE INCOMPLETE AND NEVER CACHED, A FAILED WRITE ONLY COSTS THE NEXT RUN.
+	if ctx.Err() == nil {
+		_ = writeCacheEntry(path, cacheEntry{Version: cacheVersion, Key: key, Findings: findings})
+	}
+
+	return findings, false
+}
+
+// filesEntry IS A CACHED LIST OF INPUT FILES, TOGETHER WITH THE FINGERPRINTS OF THOSE FILES WHEN IT WAS CACHED.
+type filesEntry struct {
+	Version      int      `json:"version"`
+	Key          string   `json:"key"`
+	Files        []string `json:"files"`
+	Fingerprints []string `json:"fingerprints"`
+}
+
+// CachedFiles RETURNS THE FILES THAT list RETURNED FOR THE SAME inputs BEFORE, AS LONG AS NONE OF THOSE FILES CHANGED.
+// A CacheableModule THAT NEEDS A COMMAND TO FIND ITS INPUT FILES THEREFORE ONLY RUNS IT WHEN SOMETHING CHANGED.
+func CachedFiles(ctx context.Context, name string, inputs CacheInputs, list func() []string) []string {
+	key := cacheKey(ctx, name, "", inputs)
+	path := utils.ProjectPath(ctx, filepath.Join(CacheDir, strings.ToLower(name)+".files.json"))
+
+	if data, err := os.ReadFile(path); err == nil { //nolint:gosec
+		var entry filesEntry
+
+		if json.Unmarshal(data, &entry) == nil && entry.Version == cacheVersion && entry.Key == key &&
+			slices.Equal(entry.Fingerprints, filesFingerprints(entry.Files)) {
+			return entry.Files
+		}
+	}
+
+	files := list()
+
+	if ctx.Err() == nil {
+		_ = writeCacheEntry(path, filesEntry{Version: cacheVersion, Key: key, Files: files, Fingerprints: filesFingerprints(files)})
+	}
+
+	return files
+}
+
+// filesFingerprints RETURNS THE fileFingerprint OF EVERY FILE.
+func filesFingerprints(files []string) []string {
+	fingerprints := make([]string, len(files))
+
+	for i, file := range files {
+		fingerprints[i] = fileFingerprint(file)
+	}
+
+	return fingerprints
+}
+
+// cacheKey HASHES THE FINGERPRINTS OF ALL INPUTS OF A MODULE, TOGETHER WITH THE PreFlight VERSION AND PROJECT.
+func cacheKey(ctx context.Context, name, version string, inputs CacheInputs) string {
+	hash := sha256.New()
+	projectDir, _ := filepath.Abs(utils.ProjectDir(ctx))
+
+	_, _ = fmt.Fprintf(hash, "module %s\nversion %s\nproject %s\n", name, version, projectDir)
+
+	for _, file := range inputs.Files {
+		if !filepath.IsAbs(file) {
+			file = utils.ProjectPath(ctx, file)
+		}
+
+		_, _ = fmt.Fprintf(hash, "file %s %s\n", file, fileFingerprint(file))
+	}
+
+	for _, tool := range inputs.Tools {
+		_, _ = fmt.Fprintf(hash, "tool %s %s\n", tool, toolFingerprint(tool))
+	}
+
+	for _, setting := range inputs.Settings {
+		_, _ = fmt.Fprintf(hash, "setting %q\n", setting)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// fileFingerprint RETURNS THE CONTENT HASH OF A FILE, OR THE MODIFICATION TIME OF A DIRECTORY.
+func fileFingerprint(path string) string {
+	info, err := os.Stat(path)
+
+	if err != nil {
+		return "missing"
+	}
+
+	if info.IsDir() {
+		return fmt.Sprintf("dir %d", info.ModTime().UnixNano())
+	}
+
+	file, err := os.Open(path) //nolint:gosec
+
+	if err != nil {
+		return "unreadable"
+	}
+
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+
+	if _, err := io.Copy(hash, file); err != nil {
+		return "unreadable"
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// toolFingerprint RETURNS THE PATH, SIZE AND MODIFICATION TIME OF A TOOL BINARY FOUND IN PATH.
+func toolFingerprint(tool string) string {
+	path, err := exec.LookPath(tool)
+
+	if err != nil {
+		return "missing"
+	}
+
+	// FOLLOW SYMLINKS, SO SWITCHING VERSIONS WITH A VERSION MANAGER CHANGES THE FINGERPRINT.
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	info, err := os.Stat(path)
+
+	if err != nil {
+		return "missing"
+	}
+
+	return fmt.Sprintf("%s %d %d", path, info.Size(), info.ModTime().UnixNano())
+}
+
+// readCacheEntry RETURNS THE CACHED FINDINGS WHEN THE ENTRY AT path MATCHES key.
+func readCacheEntry(path, key string) ([]Finding, bool) {
+	data, err := os.ReadFile(path) //nolint:gosec
+
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Version != cacheVersion || entry.Key != key {
+		return nil, false
+	}
+
+	return entry.Findings, true
+}
+
+// writeCacheEntry ATOMICALLY REPLACES THE CACHE ENTRY AT path, SO CONCURRENT RUNS NEVER READ A PARTIAL ENTRY.
+// THE CACHE DIRECTORY IS CREATED WITH A .gitignore, SO IT IS NEVER COMMITTED.
+func writeCacheEntry(path string, entry any) error {
+	dir := filepath.Dir(path)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gosec
+		return err
+	}
+
+	gitignore := filepath.Join(dir, ".gitignore")
+
+	if _, err := os.Stat(gitignore); os.IsNotExist(err) {
+		_ = os.WriteFile(gitignore, []byte("*\n"), 0o644) //nolint:gosec
+	}
+
+	data, err := json.Marshal(entry)
+
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(dir, ".entry-*")
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
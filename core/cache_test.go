@@ -0,0 +1,191 @@
+package core
+
+import (
+	"context"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// cacheableModule IS A fakeModule WITH FIXED CacheInputs.
+type cacheableModule struct {
+	fakeModule
+	inputs CacheInputs
+}
+
+func (m cacheableModule) CacheInputs(_ context.Context) CacheInputs {
+	return m.inputs
+}
+
+// writeFile WRITES content TO path AND FAILS THE TEST ON ERROR.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o755); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fixture tools are shell scripts")
+	}
+
+	dir := t.TempDir()
+	bin := t.TempDir()
+	t.Setenv("PATH", bin)
+
+	writeFile(t, filepath.Join(dir, "composer.json"), `{"require":{"php":"^8.2"}}`)
+	writeFile(t, filepath.Join(bin, "fixture-tool"), "#!/bin/sh\n")
+
+	if err := os.Mkdir(filepath.Join(dir, "vendor"), 0o755); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	ctx := utils.WithProjectDir(context.Background(), dir)
+	inputs := CacheInputs{Files: []string{"composer.json", "vendor"}, Tools: []string{"fixture-tool"}, Settings: []string{"^8.2"}}
+	key := cacheKey(ctx, "PHP", "1.0.0", inputs)
+
+	if again := cacheKey(ctx, "PHP", "1.0.0", inputs); again != key {
+		t.Fatal("cacheKey() changed without any change to the inputs")
+	}
+
+	tests := []struct {
+		name     string
+		change   func()
+		version  string
+		settings []string
+	}{
+		{
+			name:   "manifest",
+			change: func() { writeFile(t, filepath.Join(dir, "composer.json"), `{"require":{"php":"^8.3"}}`) },
+		},
+		{
+			name: "directory modification time",
+			change: func() {
+				later := time.Now().Add(time.Hour)
+
+				if err := os.Chtimes(filepath.Join(dir, "vendor"), later, later); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name:   "tool binary",
+			change: func() { writeFile(t, filepath.Join(bin, "fixture-tool"), "#!/bin/sh\necho 2.0.0\n") },
+		},
+		{
+			name:     "settings",
+			settings: []string{"^8.3"},
+		},
+		{
+			name:    "PreFlight version",
+			version: "1.1.0",
+		},
+	}
+
+	for _, test := range tests {
+		if test.change != nil {
+			test.change()
+		}
+
+		version, changed := "1.0.0", inputs
+
+		if test.version != "" {
+			version = test.version
+		}
+
+		if test.settings != nil {
+			changed.Settings = test.settings
+		}
+
+		if next := cacheKey(ctx, "PHP", version, changed); next == key {
+			t.Errorf("%s: cacheKey() did not change", test.name)
+		}
+
+		// THE NEXT CHANGE IS COMPARED AGAINST THE CURRENT STATE OF THE PROJECT.
+		key = cacheKey(ctx, "PHP", "1.0.0", inputs)
+	}
+}
+
+func TestCheckModuleCache(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "composer.json"), `{}`)
+
+	var runs atomic.Int32
+
+	module := cacheableModule{
+		fakeModule: fakeModule{name: "PHP", findings: []Finding{{Severity: SeveritySuccess, RuleID: RulePHPVersion}}, runs: &runs},
+		inputs:     CacheInputs{Files: []string{"composer.json"}},
+	}
+
+	ctx := utils.WithProjectDir(context.Background(), dir)
+	options := RunnerOptions{Version: "1.0.0", Cache: true}
+
+	if _, cached := checkModule(ctx, module, options); cached {
+		t.Fatal("first run was served from the cache")
+	}
+
+	if findings, cached := checkModule(ctx, module, options); !cached || len(findings) != 1 {
+		t.Fatalf("second run: cached = %v, findings = %v, want one cached finding", cached, findings)
+	}
+
+	// A CORRUPT ENTRY IS A CACHE MISS, THE MODULE RUNS AGAIN AND REPLACES IT.
+	writeFile(t, filepath.Join(dir, CacheDir, "php.cache.json"), `{"version":1,"key":`)
+
+	if _, cached := checkModule(ctx, module, options); cached {
+		t.Error("corrupt entry was served from the cache")
+	}
+
+	if _, cached := checkModule(ctx, module, options); !cached {
+		t.Error("replaced entry was not served from the cache")
+	}
+
+	if got := runs.Load(); got != 2 {
+		t.Errorf("module ran %d times, want 2", got)
+	}
+}
+
+func TestCachedFiles(t *testing.T) {
+	dir := t.TempDir()
+	ini := filepath.Join(dir, "php.ini")
+	writeFile(t, ini, "extension=intl\n")
+
+	ctx := utils.WithProjectDir(context.Background(), dir)
+
+	var runs int
+
+	list := func() []string {
+		runs++
+		return []string{ini}
+	}
+
+	inputs := CacheInputs{Settings: []string{"/etc/php"}}
+
+	for range 2 {
+		if files := CachedFiles(ctx, "php-ini", inputs, list); len(files) != 1 || files[0] != ini {
+			t.Fatalf("CachedFiles() = %v, want [%s]", files, ini)
+		}
+	}
+
+	if runs != 1 {
+		t.Fatalf("list ran %d times for unchanged files, want 1", runs)
+	}
+
+	writeFile(t, ini, "extension=intl\nextension=gd\n")
+	CachedFiles(ctx, "php-ini", inputs, list)
+
+	if runs != 2 {
+		t.Errorf("list ran %d times after a listed file changed, want 2", runs)
+	}
+
+	CachedFiles(ctx, "php-ini", CacheInputs{Settings: []string{"/usr/local/etc/php"}}, list)
+
+	if runs != 3 {
+		t.Errorf("list ran %d times after the inputs changed, want 3", runs)
+	}
+}
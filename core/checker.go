@@ -1,239 +1,576 @@
 package core
 
 import (
-	"PreFlight/utils"
 	"context"
 	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 )
 
+// CheckResult HOLDS THE FINDINGS OF A SINGLE MODULE.
 type CheckResult struct {
-	Scope     string
-	Errors    []string
-	Warnings  []string
-	Successes []string
+	Scope    string
+	Findings []Finding
+	Duration time.Duration
+
+	// TimedOut IS SET WHEN THE MODULE DID NOT COMPLETE BEFORE ITS TIMEOUT.
+	TimedOut bool
+
+	// Unverified LISTS THE REQUIREMENTS A TIMED OUT MODULE DID NOT VERIFY.
+	Unverified []string
+
+	// BlockedBy LISTS THE FAILED PREREQUISITES THAT PREVENTED THE MODULE FROM RUNNING.
+	BlockedBy []string
+
+	// Baselined HOLDS THE KNOWN FINDINGS HIDDEN BY THE BASELINE, THEY DO NOT AFFECT THE STATUS.
+	Baselined []Finding
+
+	// Cached IS SET WHEN THE FINDINGS WERE SERVED FROM THE CACHE INSTEAD OF RUNNING THE MODULE.
+	Cached bool
+}
+
+// Errors RETURNS THE FINDINGS WITH ERROR SEVERITY.
+func (r CheckResult) Errors() []Finding {
+	return r.filter(SeverityError)
+}
+
+// Warnings RETURNS THE FINDINGS WITH WARNING SEVERITY.
+func (r CheckResult) Warnings() []Finding {
+	return r.filter(SeverityWarning)
+}
+
+// Successes RETURNS THE FINDINGS WITH SUCCESS SEVERITY.
+func (r CheckResult) Successes() []Finding {
+	return r.filter(SeveritySuccess)
 }
 
-func RunChecks(ctx context.Context) int {
-	modules := SortModules(GetModules())
-	categorizedResults := make([]CheckResult, 0, len(modules))
-	ow := utils.NewOutputWriter()
+// filter RETURNS THE FINDINGS MATCHING THE GIVEN SEVERITY IN THEIR ORIGINAL ORDER.
+func (r CheckResult) filter(severity Severity) []Finding {
+	var findings []Finding
 
-	if !ow.Println(utils.Bold + utils.Blue + "\n╭─────────────────────────────────────────╮" + utils.Reset) {
-		return 0
+	for _, finding := range r.Findings {
+		if finding.Severity == severity {
+			findings = append(findings, finding)
+		}
 	}
 
-	if !ow.Println(utils.Bold + utils.Blue + "│" + utils.Cyan + utils.Bold + "  🚀 PreFlight Checker  " + utils.Reset) {
-		return 0
+	return findings
+}
+
+// CheckOptions CONFIGURES HOW RunChecks RUNS THE MODULES AND REPORTS THEIR RESULTS.
+type CheckOptions struct {
+	RunnerOptions
+
+	// Format OF THE REPORT, SEE ReportFormats.
+	Format string
+
+	// Output FILE FOR THE REPORT, STDOUT IS USED WHEN EMPTY.
+	Output string
+
+	// Template FILE USED BY THE template FORMAT.
+	Template string
+}
+
+// RunChecks RUNS THE MODULES OF A REGISTRY AND RENDERS THE REPORT, RETURNING THE EXIT CODE.
+func RunChecks(ctx context.Context, registry *Registry, options CheckOptions) int {
+	renderer, err := prepareChecks(&options)
+
+	if err != nil {
+		fmt.Println(utils.Red + "Failed to run checks: " + err.Error() + utils.Reset)
+		return ExitConfigError
 	}
 
-	if !ow.Println(utils.Bold + utils.Blue + "╰─────────────────────────────────────────╯" + utils.Reset) {
-		return 0
+	report, err := NewRunner(registry, options.RunnerOptions).Run(ctx)
+
+	if err != nil {
+		fmt.Println(utils.Red + "Failed to run checks: " + err.Error() + utils.Reset)
+		return ExitConfigError
 	}
 
-	if !ow.Println(utils.Bold + "\nProcessing modules.." + utils.Reset) {
-		return 0
+	if err := writeOutput(options.Output, func(w io.Writer) error { return renderer(w, report) }); err != nil {
+		fmt.Println(utils.Red + "Failed to write report: " + err.Error() + utils.Reset)
+		return ExitConfigError
 	}
 
-	for _, module := range modules {
-		select {
-		case <-ctx.Done():
-			if !ow.Println("\nChecks canceled...") {
-				return 0
-			}
-			return 1
-		default:
-		}
+	return report.ExitCode()
+}
 
-		moduleStart := time.Now()
+// RunRecursiveChecks CHECKS EVERY PROJECT BELOW root AND RENDERS A REPORT GROUPED BY PROJECT, RETURNING THE AGGREGATE EXIT CODE.
+// THE ROOT IS CHECKED WITH ALL MODULES, NESTED PROJECTS ONLY WITH THE MODULES OF THEIR DETECTED ECOSYSTEMS.
+func RunRecursiveChecks(ctx context.Context, registry *Registry, root string, projects []string, options CheckOptions) int {
+	renderer, err := prepareChecks(&options)
 
-		if !ow.Printf("  %s %s %s", utils.Yellow+utils.TimeGlass+utils.Reset, utils.Bold+module.Name()+utils.Reset, utils.Yellow+"..."+utils.Reset) {
-			return 0
-		}
+	if err != nil {
+		fmt.Println(utils.Red + "Failed to run checks: " + err.Error() + utils.Reset)
+		return ExitConfigError
+	}
 
-		errors, warnings, successes := module.CheckRequirements(ctx)
+	// THE TIMEOUT LIMITS ALL PROJECTS TOGETHER, NOT EACH ONE.
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
 
-		moduleDuration := time.Since(moduleStart)
+		options.Timeout = 0
+	}
 
-		if len(errors) == 0 && len(warnings) == 0 && len(successes) == 0 {
-			if !ow.Printf("\r%s\r", strings.Repeat(" ", 50)) {
-				return 0
-			}
+	if !slices.Contains(projects, ".") {
+		projects = append([]string{"."}, projects...)
+	}
+
+	ow := utils.NewOutputWriterTo(options.Progress)
+	reports := make([]ProjectReport, 0, len(projects))
+
+	for _, dir := range projects {
+		runnerOptions := options.RunnerOptions
+		runnerOptions.ProjectDir = filepath.Join(root, dir)
+		runnerOptions.EcosystemsOnly = dir != "."
+
+		ow.Println(utils.Bold + utils.Cyan + "\nProject: " + dir + utils.Reset)
+
+		report, err := NewRunner(registry, runnerOptions).Run(ctx)
 
+		if err != nil {
+			fmt.Println(utils.Red + "Failed to run checks of " + dir + ": " + err.Error() + utils.Reset)
+			return ExitConfigError
+		}
+
+		// A ROOT THAT IS NOT A PROJECT ITSELF IS LEFT OUT WHEN NONE OF ITS MODULES APPLIED.
+		if dir == "." && len(report.Results) == 0 && !slices.ContainsFunc(report.Detections, func(d Detection) bool { return d.Detected }) {
 			continue
 		}
 
-		var statusColor, statusSymbol string
+		reports = append(reports, ProjectReport{Dir: dir, CheckReport: report})
+	}
+
+	merged := MergeProjectReports(reports)
+
+	// TEXT AND JSON GROUP THE REPORT BY PROJECT, THE OTHER FORMATS RENDER THE MERGED REPORT WITH PREFIXED SCOPES.
+	render := func(w io.Writer) error { return renderer(w, merged) }
+
+	switch options.Format {
+	case FormatText:
+		render = func(w io.Writer) error { return renderProjectsText(w, reports, merged) }
+	case FormatJSON:
+		render = func(w io.Writer) error { return renderProjectsJSON(w, reports) }
+	}
+
+	if err := writeOutput(options.Output, render); err != nil {
+		fmt.Println(utils.Red + "Failed to write report: " + err.Error() + utils.Reset)
+		return ExitConfigError
+	}
+
+	return merged.ExitCode()
+}
+
+// prepareChecks RESOLVES THE RENDERER OF THE REPORT FORMAT AND PRINTS THE BANNER TO THE PROGRESS OUTPUT.
+func prepareChecks(options *CheckOptions) (ReportRenderer, error) {
+	if options.Format == "" {
+		options.Format = FormatText
+	}
+
+	options.Format = strings.ToLower(options.Format)
+	renderer, err := getReportRenderer(options.Format, options.Template)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// MACHINE-READABLE REPORTS KEEP STDOUT CLEAN BY WRITING PROGRESS TO STDERR.
+	options.Progress = os.Stdout
+
+	if !isTerminalFormat(options.Format) {
+		options.Progress = os.Stderr
+	}
+
+	ow := utils.NewOutputWriterTo(options.Progress)
+	ow.Println(utils.Bold + utils.Blue + "\n╭─────────────────────────────────────────╮" + utils.Reset)
+	ow.Println(utils.Bold + utils.Blue + "│" + utils.Cyan + utils.Bold + "  🚀 PreFlight Checker  " + utils.Reset)
+	ow.Println(utils.Bold + utils.Blue + "╰─────────────────────────────────────────╯" + utils.Reset)
+
+	return renderer, nil
+}
+
+// collectResults RUNS UP TO jobs MODULES CONCURRENTLY WHILE PRINTING PROGRESS.
+// A MODULE ONLY STARTS ONCE ITS PREREQUISITES COMPLETED, AND IS REPORTED AS BLOCKED WHEN ONE OF THEM FAILED.
+// THE BASELINE IS APPLIED AS SOON AS A MODULE COMPLETES, SO KNOWN FINDINGS NEITHER FAIL NOR BLOCK ANYTHING.
+// RESULTS ARE ALWAYS RETURNED IN THE ORDER OF modules, REGARDLESS OF WHICH MODULE FINISHES FIRST.
+func collectResults(ctx context.Context, ow *utils.OutputWriter, live bool, modules []Module, prerequisites [][]int, baseline *Baseline, options RunnerOptions) CheckReport {
+	report := CheckReport{
+		Results:   make([]CheckResult, 0, len(modules)),
+		StartedAt: time.Now(),
+	}
+
+	jobs := options.Jobs
+
+	if jobs <= 0 || jobs > len(modules) {
+		jobs = len(modules)
+	}
+
+	ow.Println(utils.Bold + "\nProcessing modules.." + utils.Reset)
+
+	progress := newProgressPrinter(ow, live, moduleNames(modules), options.OnProgress)
+	results := make([]*CheckResult, len(modules))
+	semaphore := make(chan struct{}, max(jobs, 1))
+	done := make([]chan struct{}, len(modules))
+
+	for i := range modules {
+		done[i] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+
+	for i, module := range modules {
+		wg.Add(1)
+
+		go func(i int, module Module) {
+			defer wg.Done()
+			defer close(done[i])
+
+			// WAIT FOR THE PREREQUISITES BEFORE TAKING A SLOT, SO WAITING MODULES NEVER STARVE RUNNING ONES.
+			var blockedBy []string
+
+			for _, p := range prerequisites[i] {
+				select {
+				case <-done[p]:
+				case <-ctx.Done():
+				}
+
+				if ctx.Err() == nil && prerequisiteFailed(results[p]) {
+					blockedBy = append(blockedBy, modules[p].Name())
+				}
+			}
+
+			if ctx.Err() == nil && len(blockedBy) > 0 {
+				result := blockedResult(module, blockedBy)
+				results[i] = &result
+				progress.Done(i, results[i], 0)
+				return
+			}
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+			}
+
+			if ctx.Err() != nil {
+				progress.Skip(i, "canceled")
+				return
+			}
+
+			progress.Start(i)
+			moduleStart := time.Now()
+
+			moduleCtx, cancel := withModuleTimeout(ctx, module.Name(), options.ModuleTimeouts)
+			defer cancel()
+
+			findings, cached := checkModule(moduleCtx, module, options)
+
+			moduleDuration := time.Since(moduleStart)
+
+			if moduleCtx.Err() != nil {
+				result := timedOutResult(moduleCtx, module, findings, moduleDuration)
+
+				if baseline != nil {
+					baseline.applyResult(&result)
+				}
 
-		if len(errors) > 0 {
-			statusColor = utils.Red
-			statusSymbol = utils.CrossMark
-		} else if len(warnings) > 0 {
-			statusColor = utils.Yellow
-			statusSymbol = utils.WarningSign
-		} else {
-			statusColor = utils.Green
-			statusSymbol = utils.CheckMark
+				results[i] = &result
+				progress.Done(i, results[i], moduleDuration)
+				return
+			}
+
+			if len(findings) == 0 {
+				progress.Done(i, nil, moduleDuration)
+				return
+			}
+
+			results[i] = &CheckResult{
+				Scope:    module.Name(),
+				Findings: findings,
+				Duration: moduleDuration,
+				Cached:   cached,
+			}
+
+			if baseline != nil {
+				baseline.applyResult(results[i])
+			}
+
+			progress.Done(i, results[i], moduleDuration)
+		}(i, module)
+	}
+
+	wg.Wait()
+
+	for _, result := range results {
+		if result != nil {
+			report.Results = append(report.Results, *result)
 		}
+	}
+
+	if ctx.Err() != nil {
+		ow.Println("\nChecks canceled...")
+		report.Canceled = true
+	}
+
+	ow.PrintNewLines(1)
+	report.EndedAt = time.Now()
+
+	return report
+}
+
+// withModuleTimeout DERIVES THE CONTEXT OF A SINGLE MODULE FROM ITS CONFIGURED TIMEOUT.
+func withModuleTimeout(ctx context.Context, name string, timeouts map[string]time.Duration) (context.Context, context.CancelFunc) {
+	timeout, ok := timeouts[strings.ToLower(name)]
 
-		if !ow.Printf("\r  %s %s completed (%dms)\n", statusColor+statusSymbol+utils.Reset, utils.Bold+module.Name()+utils.Reset, moduleDuration.Milliseconds()) {
-			return 0
+	if !ok {
+		timeout = timeouts["*"]
+	}
+
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// prerequisiteFailed REPORTS WHETHER A PREREQUISITE RESULT PREVENTS ITS DEPENDENT MODULES FROM RUNNING.
+// ONLY A TOOL THAT IS NOT USABLE BLOCKS THE DEPENDENTS, A MISSING EXTENSION OR A MISMATCHING VERSION DOES NOT.
+func prerequisiteFailed(result *CheckResult) bool {
+	if result == nil {
+		return false
+	}
+
+	if result.TimedOut || len(result.BlockedBy) > 0 {
+		return true
+	}
+
+	for _, finding := range result.Errors() {
+		if LookupRule(finding.RuleID).Blocking {
+			return true
 		}
+	}
+
+	return false
+}
+
+// blockedResult BUILDS THE RESULT OF A MODULE THAT WAS NOT RUN BECAUSE A PREREQUISITE FAILED.
+// ITS REQUIREMENTS WERE NOT VERIFIED, SO THE BLOCKED MODULE FAILS THE RUN LIKE ANY OTHER ERROR.
+func blockedResult(module Module, blockedBy []string) CheckResult {
+	return CheckResult{
+		Scope: module.Name(),
+		Findings: []Finding{{
+			Severity:    SeverityError,
+			Module:      module.Name(),
+			RuleID:      RuleModuleBlocked,
+			Remediation: "Resolve the errors of " + strings.Join(blockedBy, ", ") + " first",
+			Message:     fmt.Sprintf("Blocked by %s, %s was not checked.", strings.Join(blockedBy, ", "), module.Name()),
+		}},
+		BlockedBy: blockedBy,
+	}
+}
+
+// timedOutResult BUILDS THE RESULT OF A MODULE THAT DID NOT COMPLETE, LISTING WHAT IT DID AND DID NOT VERIFY.
+// A MODULE STOPS AT THE FIRST STEP AFTER ITS TIMEOUT, SO EVERY REQUIREMENT WITHOUT A FINDING IS REPORTED AS NOT VERIFIED.
+func timedOutResult(ctx context.Context, module Module, findings []Finding, duration time.Duration) CheckResult {
+	verified := make(map[string]struct{}, len(findings))
+
+	for _, finding := range findings {
+		verified[finding.Subject] = struct{}{}
+	}
+
+	var requirements, unverified []string
+
+	if lister, ok := module.(RequirementLister); ok {
+		requirements = lister.Requirements(ctx)
+	}
 
-		result := CheckResult{
-			Scope:     module.Name(),
-			Errors:    errors,
-			Warnings:  warnings,
-			Successes: successes,
+	for _, requirement := range requirements {
+		if _, ok := verified[requirement]; !ok {
+			unverified = append(unverified, requirement)
 		}
+	}
+
+	message := fmt.Sprintf("Timed out after %s, verified %d of %d requirements.", duration.Round(time.Millisecond), len(requirements)-len(unverified), len(requirements))
 
-		categorizedResults = append(categorizedResults, result)
+	if len(unverified) > 0 {
+		message += " Not verified: " + strings.Join(unverified, ", ") + "."
 	}
 
-	if !ow.PrintNewLines(1) {
-		return 0
+	timeout := Finding{
+		Severity:    SeverityError,
+		Module:      module.Name(),
+		RuleID:      RuleModuleTimeout,
+		Remediation: "Increase the module or command timeout",
+		Message:     message,
 	}
 
-	printResults(categorizedResults)
-	return finalMessage(categorizedResults)
+	return CheckResult{
+		Scope:      module.Name(),
+		Findings:   append(findings, timeout),
+		Duration:   duration,
+		TimedOut:   true,
+		Unverified: unverified,
+	}
 }
 
-func printResults(results []CheckResult) {
-	ow := utils.NewOutputWriter()
+// renderText WRITES THE HUMAN-READABLE REPORT.
+func renderText(w io.Writer, report CheckReport) error {
+	ow := utils.NewOutputWriterTo(w)
+
+	if !printDetections(ow, report.Detections) || !printResults(ow, report.Results) || !finalMessage(ow, report) {
+		return fmt.Errorf("unable to write report")
+	}
 
+	return nil
+}
+
+// printDetections PRINTS WHICH ECOSYSTEMS WERE DETECTED AND WHY, SO SKIPPED MODULES ARE NEVER A SURPRISE.
+func printDetections(ow *utils.OutputWriter, detections []Detection) bool {
+	if len(detections) == 0 {
+		return true
+	}
+
+	if !ow.Println(utils.Bold + "Detected ecosystems:" + utils.Reset) {
+		return false
+	}
+
+	for _, detection := range detections {
+		if detection.Detected {
+			ow.Printf("%s    %s %s (%s)%s\n", utils.Green, utils.CheckMark, detection.Module, detection.Reason, utils.Reset)
+			continue
+		}
+
+		ow.Printf("%s    - %s skipped (%s)%s\n", utils.Dim, detection.Module, detection.Reason, utils.Reset)
+	}
+
+	return true
+}
+
+// printResults PRINTS THE FINDINGS OF EACH SCOPE GROUPED BY SEVERITY.
+// SCOPES WHOSE FINDINGS WERE ALL HIDDEN BY THE BASELINE ARE LEFT OUT.
+func printResults(ow *utils.OutputWriter, results []CheckResult) bool {
 	for _, result := range results {
+		if len(result.Findings) == 0 {
+			continue
+		}
+
 		var sb strings.Builder
 
 		sb.WriteString(utils.Reset)
 		sb.WriteString(utils.Bold)
 		sb.WriteString("\nScope: ")
 		sb.WriteString(result.Scope)
+
+		if result.TimedOut {
+			sb.WriteString(utils.Red + " (timed out)")
+		}
+
+		if len(result.BlockedBy) > 0 {
+			sb.WriteString(utils.Red + " (blocked by " + strings.Join(result.BlockedBy, ", ") + ")")
+		}
+
 		sb.WriteString(utils.Reset)
 
 		if !ow.Println(sb.String()) {
-			return
+			return false
 		}
 
-		if len(result.Successes) > 0 {
+		if len(result.Successes()) > 0 {
 			if !ow.Println(utils.Green + "  Successes:" + utils.Reset) {
-				return
+				return false
 			}
 
-			printMessages(ow, result.Successes, utils.Green, utils.CheckMark)
+			printMessages(ow, result.Successes(), utils.Green, utils.CheckMark)
 
 			if !ow.Println("") {
-				return
+				return false
 			}
 		}
 
-		if len(result.Warnings) > 0 {
+		if len(result.Warnings()) > 0 {
 			if !ow.Println(utils.Yellow + "  Warnings:" + utils.Reset) {
-				return
+				return false
 			}
 
-			printMessages(ow, result.Warnings, utils.Yellow, utils.WarningSign)
+			printMessages(ow, result.Warnings(), utils.Yellow, utils.WarningSign)
 
 			if !ow.Println("") {
-				return
+				return false
 			}
 		}
 
-		if len(result.Errors) > 0 {
+		if len(result.Errors()) > 0 {
 			if !ow.Println(utils.Red + "  Errors:" + utils.Reset) {
-				return
+				return false
 			}
 
-			printMessages(ow, result.Errors, utils.Red, utils.CrossMark)
+			printMessages(ow, result.Errors(), utils.Red, utils.CrossMark)
 
 			if !ow.Println("") {
-				return
+				return false
 			}
 		}
 	}
+
+	return true
 }
 
-// printMessages
-func printMessages(ow *utils.OutputWriter, messages []string, color string, symbol string) {
-	var isUnderVersionMatch bool
-
-	for _, msg := range messages {
-		indentLevel := 2
-		msgLower := strings.ToLower(msg)
-
-		if strings.Contains(msgLower, "version matches") ||
-			(strings.Contains(msgLower, "installed") &&
-				(strings.Contains(msgLower, "php") ||
-					strings.Contains(msgLower, "composer") ||
-					strings.Contains(msgLower, "node") ||
-					strings.Contains(msgLower, "go"))) {
-			isUnderVersionMatch = true
-			indentLevel = 4
-		} else if strings.Contains(msg, "Scope:") {
-			isUnderVersionMatch = false
-			indentLevel = 2
-		} else if strings.Contains(msg, ".json found") ||
-			strings.Contains(msg, "go.mod found") {
-			isUnderVersionMatch = true
-			indentLevel = 4
-		} else if !strings.Contains(msgLower, "version") &&
-			!strings.Contains(msgLower, "installed") &&
-			!strings.Contains(msgLower, ".json") &&
-			!strings.Contains(msgLower, "go.mod") {
-			isUnderVersionMatch = false
-		}
-
-		if isUnderVersionMatch && (strings.Contains(msgLower, "installed package") ||
-			strings.Contains(msgLower, "missing package") ||
-			strings.Contains(msgLower, "installed dependency") ||
-			strings.Contains(msgLower, "missing dependency") ||
-			strings.Contains(msgLower, "installed extension") ||
-			strings.Contains(msgLower, "missing extension") ||
-			strings.Contains(msgLower, "installed module")) ||
-			strings.Contains(msgLower, "missing module") {
+// printMessages PRINTS FINDINGS, NESTING INDIVIDUAL DEPENDENCIES BELOW THEIR TOOLS.
+func printMessages(ow *utils.OutputWriter, findings []Finding, color string, symbol string) {
+	for _, finding := range findings {
+		indentLevel := 4
+
+		if finding.IsDependency() {
 			indentLevel = 6
-		} else if !strings.Contains(msg, "Scope:") {
-			indentLevel = 4
 		}
 
-		ow.Printf("%s%s %s %s\n", color, strings.Repeat(" ", indentLevel), symbol, msg)
+		ow.Printf("%s%s %s %s%s\n", color, strings.Repeat(" ", indentLevel), symbol, finding.Message, utils.Reset)
 	}
 }
 
-func finalMessage(results []CheckResult) int {
-	var totalErrors, totalWarnings int
-
-	for _, result := range results {
-		totalErrors += len(result.Errors)
-		totalWarnings += len(result.Warnings)
+// statusSummary RETURNS THE ICON, COLOR AND TEXT DESCRIBING A REPORT STATUS.
+func statusSummary(status Status) (icon, color, text string) {
+	switch status {
+	case StatusCanceled:
+		return utils.CrossMark, utils.Red, "Check canceled before all modules completed."
+	case StatusTimedOut:
+		return utils.TimeGlass, utils.Red, "Check timed out, some requirements were not verified."
+	case StatusError:
+		return utils.CrossMark, utils.Red, "Check completed, please resolve."
+	case StatusWarning:
+		return utils.WarningSign, utils.Yellow, "Check completed with warnings, please review."
+	default:
+		return utils.CheckMark, utils.Green, "Check completed successfully!"
 	}
+}
 
-	var statusIcon, statusColor, statusText string
-	var exitCode int
+// finalMessage PRINTS THE SUMMARY BOX WITH THE OVERALL STATUS.
+func finalMessage(ow *utils.OutputWriter, report CheckReport) bool {
+	statusIcon, statusColor, statusText := statusSummary(report.Status())
+	currentTime := report.EndedAt.Format(endedAtLayout)
 
-	if totalErrors > 0 {
-		statusIcon = utils.CrossMark
-		statusColor = utils.Red
-		statusText = "Check completed, please resolve."
-		exitCode = 1
-	} else if totalWarnings > 0 {
-		statusIcon = utils.WarningSign
-		statusColor = utils.Yellow
-		statusText = "Check completed with warnings, please review."
-		exitCode = 0
-	} else {
-		statusIcon = utils.CheckMark
-		statusColor = utils.Green
-		statusText = "Check completed successfully!"
-		exitCode = 0
+	if !ow.Println(utils.Bold+utils.Blue+"\n╭────────────────────────────────────────────────────────────────╮"+utils.Reset) ||
+		!ow.Println(utils.Bold+utils.Blue+"│ "+statusColor+statusIcon+" Status: "+statusText+utils.Reset) {
+		return false
 	}
 
-	currentTime := time.Now().Format("02-01-2006 15:04:05")
-
-	fmt.Println(utils.Bold + utils.Blue + "\n╭────────────────────────────────────────────────────────────────╮" + utils.Reset)
-	fmt.Println(utils.Bold + utils.Blue + "│ " + statusColor + statusIcon + " Status: " + statusText + utils.Reset)
-	fmt.Println(utils.Bold + utils.Blue + "│ " + utils.Dim + utils.Clock + " Ended: " + currentTime + utils.Reset)
-	fmt.Println(utils.Bold + utils.Blue + "╰────────────────────────────────────────────────────────────────╯" + utils.Reset)
+	if baselined := report.Baselined(); baselined > 0 {
+		if !ow.Printf("%s│ %s %s  %d known findings hidden by the baseline%s\n", utils.Bold+utils.Blue, utils.Dim, utils.CheckMark, baselined, utils.Reset) {
+			return false
+		}
+	}
 
-	return exitCode
+	return ow.Println(utils.Bold+utils.Blue+"│ "+utils.Dim+utils.Clock+" Ended: "+currentTime+utils.Reset) &&
+		ow.Println(utils.Bold+utils.Blue+"╰────────────────────────────────────────────────────────────────╯"+utils.Reset)
 }
+
+// endedAtLayout IS THE TIME LAYOUT USED IN HUMAN-READABLE SUMMARIES.
+const endedAtLayout = "02-01-2006 15:04:05"
@@ -1,22 +1,24 @@
 package core
 
 import (
-	"PreFlight/config"
-	"PreFlight/utils"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/config"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"io"
 	"sort"
 	"strings"
 )
 
 // DependencyResult HOLDS THE RESULT OF ALL FOUND DEPENDENCIES.
 type DependencyResult struct {
-	Dependencies map[string][]string
+	Dependencies map[string][]string `json:"dependencies"`
 }
 
 // dependencyFetcher IS A FUNCTION SIGNATURE FOR FETCHING DEPENDENCIES.
-type dependencyFetcher func() (string, []string, error)
+type dependencyFetcher func(projectDir string) (string, []string, error)
 
-// GetAllDependencies COLLECTS DEPENDENCIES FROM SUPPORTED PACKAGE MANAGERS.
-func GetAllDependencies(only ...string) DependencyResult {
+// GetAllDependencies COLLECTS DEPENDENCIES FROM SUPPORTED PACKAGE MANAGERS OF THE PROJECT IN projectDir.
+func GetAllDependencies(projectDir string, only ...string) DependencyResult {
 	allowed := make(map[string]bool)
 
 	for _, name := range only {
@@ -38,7 +40,7 @@ func GetAllDependencies(only ...string) DependencyResult {
 			continue
 		}
 
-		depName, deps, err := fetch()
+		depName, deps, err := fetch(projectDir)
 
 		if err == nil && len(deps) > 0 {
 			sort.Strings(deps)
@@ -50,8 +52,8 @@ func GetAllDependencies(only ...string) DependencyResult {
 }
 
 // fetchComposerDependencies FETCH Composer DEPENDENCIES.
-func fetchComposerDependencies() (string, []string, error) {
-	cfg := config.LoadComposerConfig()
+func fetchComposerDependencies(projectDir string) (string, []string, error) {
+	cfg := config.LoadComposerConfig(projectDir)
 
 	if !cfg.HasJSON || cfg.Error != nil {
 		return "", nil, cfg.Error
@@ -67,8 +69,8 @@ func fetchComposerDependencies() (string, []string, error) {
 }
 
 // fetchPackageDependencies FETCH Package DEPENDENCIES.
-func fetchPackageDependencies() (string, []string, error) {
-	cfg := config.LoadPackageConfig()
+func fetchPackageDependencies(projectDir string) (string, []string, error) {
+	cfg := config.LoadPackageConfig(projectDir)
 
 	if !cfg.HasJSON || cfg.Error != nil {
 		return "", nil, cfg.Error
@@ -84,8 +86,8 @@ func fetchPackageDependencies() (string, []string, error) {
 }
 
 // fetchGoDependencies FETCH Go DEPENDENCIES.
-func fetchGoDependencies() (string, []string, error) {
-	cfg := config.LoadGoConfig()
+func fetchGoDependencies(projectDir string) (string, []string, error) {
+	cfg := config.LoadGoConfig(projectDir)
 
 	if !cfg.HasMod || cfg.Error != nil || len(cfg.Modules) == 0 {
 		return "", nil, cfg.Error
@@ -94,9 +96,105 @@ func fetchGoDependencies() (string, []string, error) {
 	return "go", cfg.Modules, nil
 }
 
+// DependencyRenderer WRITES A DEPENDENCY LIST IN A SPECIFIC FORMAT.
+type DependencyRenderer func(w io.Writer, result DependencyResult) error
+
+// dependencyRenderers MAPS EACH FORMAT NAME TO ITS RENDERER.
+var dependencyRenderers = map[string]DependencyRenderer{
+	FormatText:     renderDependenciesText,
+	FormatMarkdown: renderDependenciesMarkdown,
+	FormatHTML:     renderDependenciesHTML,
+
+	// THE TEMPLATE RENDERER IS CREATED PER RUN FROM THE --template FILE.
+	FormatTemplate: nil,
+}
+
+// ListOptions CONFIGURES HOW WriteDependencies RENDERS THE DEPENDENCY LIST.
+type ListOptions struct {
+	// Format OF THE LIST, SEE DependencyFormats.
+	Format string
+
+	// Output FILE FOR THE LIST, STDOUT IS USED WHEN EMPTY.
+	Output string
+
+	// Template FILE USED BY THE template FORMAT.
+	Template string
+}
+
+// DependencyFormats RETURNS THE NAMES OF ALL SUPPORTED DEPENDENCY LIST FORMATS.
+func DependencyFormats() []string {
+	formats := make([]string, 0, len(dependencyRenderers))
+
+	for name := range dependencyRenderers {
+		formats = append(formats, name)
+	}
+
+	sort.Strings(formats)
+
+	return formats
+}
+
+// WriteDependencies RENDERS THE DEPENDENCY LIST IN THE GIVEN FORMAT TO A FILE OR STDOUT.
+func WriteDependencies(result DependencyResult, options ListOptions) error {
+	format := strings.ToLower(options.Format)
+
+	if format == "" {
+		format = FormatText
+	}
+
+	renderer, ok := dependencyRenderers[format]
+
+	if !ok {
+		return fmt.Errorf("unknown format '%s', expected one of: %s", options.Format, strings.Join(DependencyFormats(), ", "))
+	}
+
+	if format == FormatTemplate {
+		var err error
+
+		if renderer, err = newDependencyTemplateRenderer(options.Template); err != nil {
+			return err
+		}
+	}
+
+	return writeOutput(options.Output, func(w io.Writer) error {
+		return renderer(w, result)
+	})
+}
+
+// renderDependenciesText WRITES THE HUMAN-READABLE DEPENDENCY LIST.
+func renderDependenciesText(w io.Writer, result DependencyResult) error {
+	if !printDependencies(utils.NewOutputWriterTo(w), result) {
+		return fmt.Errorf("unable to write dependencies")
+	}
+
+	return nil
+}
+
+// SortedManagers RETURNS THE PACKAGE MANAGER NAMES OF THE RESULT IN ALPHABETICAL ORDER.
+func (r DependencyResult) SortedManagers() []string {
+	pmNames := make([]string, 0, len(r.Dependencies))
+
+	for name := range r.Dependencies {
+		pmNames = append(pmNames, name)
+	}
+
+	sort.Strings(pmNames)
+
+	return pmNames
+}
+
+// displayManagerName CAPITALIZES A PACKAGE MANAGER NAME FOR DISPLAY.
+func displayManagerName(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
 // PrintDependencies PRINTS THE FOUND DEPENDENCIES.
 func PrintDependencies(result DependencyResult) bool {
-	ow := utils.NewOutputWriter()
+	return printDependencies(utils.NewOutputWriter(), result)
+}
+
+// printDependencies PRINTS THE FOUND DEPENDENCIES TO THE GIVEN WRITER.
+func printDependencies(ow *utils.OutputWriter, result DependencyResult) bool {
 
 	header := []string{
 		utils.Bold + utils.Blue + "\n╭─────────────────────────────────────────╮" + utils.Reset,
@@ -119,17 +217,9 @@ func PrintDependencies(result DependencyResult) bool {
 	}
 
 	// SORT FOR CONSISTENT OUTPUT.
-	pmNames := make([]string, 0, len(result.Dependencies))
-
-	for name := range result.Dependencies {
-		pmNames = append(pmNames, name)
-	}
-
-	sort.Strings(pmNames)
-
-	for _, name := range pmNames {
+	for _, name := range result.SortedManagers() {
 		deps := result.Dependencies[name]
-		displayName := strings.ToUpper(name[:1]) + name[1:]
+		displayName := displayManagerName(name)
 
 		ow.Printf("%s%s Dependencies:%s\n", utils.Bold, displayName, utils.Reset)
 
@@ -0,0 +1,268 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// environmentVersion IS INCREMENTED WHENEVER THE FORMAT OF AN ENVIRONMENT MANIFEST CHANGES.
+const environmentVersion = 1
+
+// Environment IS A SNAPSHOT OF EVERYTHING ON A MACHINE THE CHECKS OF A PROJECT DEPEND ON.
+type Environment struct {
+	Version    int       `json:"version"`
+	Tool       JSONTool  `json:"tool"`
+	ExportedAt time.Time `json:"exportedAt"`
+	OS         string    `json:"os"`
+	Arch       string    `json:"arch"`
+
+	// Tools MAPS EVERY DETECTED TOOL TO ITS INSTALLED VERSION.
+	Tools map[string]string `json:"tools"`
+
+	// PHP IS NIL WHEN PHP IS NOT INSTALLED.
+	PHP *EnvironmentPHP `json:"php,omitempty"`
+
+	// Dependencies MAPS A PACKAGE MANAGER (composer, package, go) TO THE INSTALLED VERSION OF EVERY DEPENDENCY.
+	Dependencies map[string]map[string]string `json:"dependencies"`
+}
+
+// EnvironmentPHP DESCRIBES THE PHP BUILD AND ITS LOADED EXTENSIONS.
+type EnvironmentPHP struct {
+	Version    string   `json:"version"`
+	BuildDate  string   `json:"buildDate"`
+	Compiler   string   `json:"compiler"`
+	Extensions []string `json:"extensions"`
+}
+
+// NewEnvironment RETURNS AN EMPTY ENVIRONMENT OF THE CURRENT MACHINE, READY TO BE FILLED BY THE MODULES.
+func NewEnvironment(version string) Environment {
+	return Environment{
+		Version:      environmentVersion,
+		Tool:         JSONTool{Name: "PreFlight", Version: version},
+		ExportedAt:   time.Now().UTC(),
+		Tools:        make(map[string]string),
+		Dependencies: make(map[string]map[string]string),
+	}
+}
+
+// environmentKey IS THE CONTEXT KEY HOLDING THE ENVIRONMENT THE CHECKS ARE EVALUATED AGAINST.
+type environmentKey struct{}
+
+// WithEnvironment RETURNS A CONTEXT WHOSE MODULES RESOLVE TOOL VERSIONS, PHP EXTENSIONS AND INSTALLED PACKAGES
+// FROM env INSTEAD OF RUNNING COMMANDS ON THIS MACHINE.
+func WithEnvironment(ctx context.Context, env *Environment) context.Context {
+	return context.WithValue(ctx, environmentKey{}, env)
+}
+
+// EnvironmentFrom RETURNS THE ENVIRONMENT STORED IN THE CONTEXT, NIL MEANS THIS MACHINE IS CHECKED.
+func EnvironmentFrom(ctx context.Context) *Environment {
+	env, _ := ctx.Value(environmentKey{}).(*Environment)
+	return env
+}
+
+// ToolVersion RETURNS THE VERSION OF A TOOL IN THE ENVIRONMENT, OR AN ERROR WHEN IT IS NOT INSTALLED THERE.
+func (e *Environment) ToolVersion(tool string) (string, error) {
+	if version := e.Tools[tool]; version != "" {
+		return version, nil
+	}
+
+	return "", fmt.Errorf("%s is not installed in the environment", tool)
+}
+
+// WriteEnvironment WRITES THE ENVIRONMENT AS JSON, STDOUT IS USED WHEN output IS EMPTY.
+func WriteEnvironment(env Environment, output string) error {
+	return writeOutput(output, func(w io.Writer) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(env)
+	})
+}
+
+// LoadEnvironment READS AN ENVIRONMENT WRITTEN BY preflight env export.
+func LoadEnvironment(path string) (Environment, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+
+	if err != nil {
+		return Environment{}, err
+	}
+
+	var env Environment
+
+	if err := json.Unmarshal(data, &env); err != nil {
+		return Environment{}, fmt.Errorf("invalid environment %s: %w", path, err)
+	}
+
+	if env.Version != environmentVersion {
+		return Environment{}, fmt.Errorf("unsupported environment version %d in %s (supported: %d)", env.Version, path, environmentVersion)
+	}
+
+	return env, nil
+}
+
+// EnvironmentDifference IS A SINGLE VALUE THAT DIFFERS BETWEEN TWO ENVIRONMENTS, EMPTY WHEN IT IS MISSING FROM ONE.
+type EnvironmentDifference struct {
+	Section string `json:"section"`
+	Name    string `json:"name"`
+	A       string `json:"a"`
+	B       string `json:"b"`
+}
+
+// DiffEnvironments RETURNS EVERY VALUE THAT DIFFERS BETWEEN TWO ENVIRONMENTS, GROUPED BY SECTION.
+func DiffEnvironments(a, b Environment) []EnvironmentDifference {
+	var differences []EnvironmentDifference
+
+	diffValues := func(section string, a, b map[string]string) {
+		for _, name := range sortedKeys(a, b) {
+			if a[name] != b[name] {
+				differences = append(differences, EnvironmentDifference{Section: section, Name: name, A: a[name], B: b[name]})
+			}
+		}
+	}
+
+	diffValues("platform", map[string]string{"os": a.OS, "arch": a.Arch}, map[string]string{"os": b.OS, "arch": b.Arch})
+	diffValues("tools", a.Tools, b.Tools)
+	diffValues("php", phpBuild(a.PHP), phpBuild(b.PHP))
+	diffValues("extensions", phpExtensions(a.PHP), phpExtensions(b.PHP))
+
+	for _, manager := range sortedKeys(a.Dependencies, b.Dependencies) {
+		diffValues(manager, a.Dependencies[manager], b.Dependencies[manager])
+	}
+
+	return differences
+}
+
+// phpBuild RETURNS THE BUILD INFO OF PHP, OR NOTHING WHEN PHP IS NOT INSTALLED.
+func phpBuild(php *EnvironmentPHP) map[string]string {
+	if php == nil {
+		return nil
+	}
+
+	return map[string]string{"version": php.Version, "build date": php.BuildDate, "compiler": php.Compiler}
+}
+
+// phpExtensions MARKS EVERY LOADED EXTENSION AS loaded, SO A MISSING EXTENSION SHOWS UP AS A DIFFERENCE.
+func phpExtensions(php *EnvironmentPHP) map[string]string {
+	if php == nil {
+		return nil
+	}
+
+	extensions := make(map[string]string, len(php.Extensions))
+
+	for _, ext := range php.Extensions {
+		extensions[ext] = "loaded"
+	}
+
+	return extensions
+}
+
+// environmentSections ARE THE TITLES OF THE SECTIONS OF A DIFF, DEPENDENCY SECTIONS ARE NAMED AFTER THEIR MANAGER.
+var environmentSections = map[string]string{
+	"platform":   "Platform",
+	"tools":      "Tools",
+	"php":        "PHP build",
+	"extensions": "PHP extensions",
+	"composer":   "Composer dependencies",
+	"package":    "Package dependencies",
+	"go":         "Go dependencies",
+}
+
+// EnvironmentDiffOptions CONFIGURES HOW WriteEnvironmentDiff RENDERS THE DIFFERENCES.
+type EnvironmentDiffOptions struct {
+	// Format IS text OR json.
+	Format string
+
+	// Output FILE, STDOUT IS USED WHEN EMPTY.
+	Output string
+
+	// A AND B NAME THE COMPARED ENVIRONMENTS, USUALLY THEIR FILES.
+	A string
+	B string
+}
+
+// JSONEnvironmentDiff IS THE JSON REPRESENTATION OF THE DIFFERENCES BETWEEN TWO ENVIRONMENTS.
+type JSONEnvironmentDiff struct {
+	A           string                  `json:"a"`
+	B           string                  `json:"b"`
+	Identical   bool                    `json:"identical"`
+	Differences []EnvironmentDifference `json:"differences"`
+}
+
+// WriteEnvironmentDiff RENDERS THE DIFFERENCES BETWEEN TWO ENVIRONMENTS.
+func WriteEnvironmentDiff(differences []EnvironmentDifference, options EnvironmentDiffOptions) error {
+	switch strings.ToLower(options.Format) {
+	case "", FormatText:
+		return writeOutput(options.Output, func(w io.Writer) error { return renderEnvironmentDiffText(w, differences, options) })
+	case FormatJSON:
+		return writeOutput(options.Output, func(w io.Writer) error {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+
+			return encoder.Encode(JSONEnvironmentDiff{
+				A:           options.A,
+				B:           options.B,
+				Identical:   len(differences) == 0,
+				Differences: append([]EnvironmentDifference{}, differences...),
+			})
+		})
+	default:
+		return fmt.Errorf("unsupported diff format: %s (supported: text, json)", options.Format)
+	}
+}
+
+// renderEnvironmentDiffText WRITES THE DIFFERENCES PER SECTION, WITH THE VALUE OF A NEXT TO THE VALUE OF B.
+func renderEnvironmentDiffText(w io.Writer, differences []EnvironmentDifference, options EnvironmentDiffOptions) error {
+	ow := utils.NewOutputWriterTo(w)
+
+	ow.Println(utils.Bold + fmt.Sprintf("\nComparing %s (A) with %s (B):", options.A, options.B) + utils.Reset)
+
+	if len(differences) == 0 {
+		if !ow.Println(utils.Green + utils.CheckMark + " Both environments are identical." + utils.Reset + "\n") {
+			return fmt.Errorf("unable to write diff")
+		}
+
+		return nil
+	}
+
+	width := 0
+
+	for _, difference := range differences {
+		width = max(width, len(difference.Name))
+	}
+
+	section := ""
+
+	for _, difference := range differences {
+		if difference.Section != section {
+			section = difference.Section
+			title, ok := environmentSections[section]
+
+			if !ok {
+				title = section + " dependencies"
+			}
+
+			ow.Println(utils.Bold + "\n" + title + ":" + utils.Reset)
+		}
+
+		switch {
+		case difference.B == "":
+			ow.Printf("%s  - %-*s  %s (missing in B)%s\n", utils.Red, width, difference.Name, difference.A, utils.Reset)
+		case difference.A == "":
+			ow.Printf("%s  + %-*s  %s (missing in A)%s\n", utils.Green, width, difference.Name, difference.B, utils.Reset)
+		default:
+			ow.Printf("%s  ~ %-*s  %s ⟶ %s%s\n", utils.Yellow, width, difference.Name, difference.A, difference.B, utils.Reset)
+		}
+	}
+
+	if !ow.Println(fmt.Sprintf("\n%s.\n", plural(len(differences), "difference"))) {
+		return fmt.Errorf("unable to write diff")
+	}
+
+	return nil
+}
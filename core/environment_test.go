@@ -0,0 +1,163 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiffEnvironments(t *testing.T) {
+	base := func() Environment {
+		env := NewEnvironment("1.0.0")
+		env.OS, env.Arch = "linux", "amd64"
+		env.Tools = map[string]string{"php": "8.3.4", "node": "20.11.1"}
+		env.PHP = &EnvironmentPHP{Version: "8.3.4", BuildDate: "Mar 12 2024", Compiler: "GCC", Extensions: []string{"intl", "mbstring"}}
+		env.Dependencies = map[string]map[string]string{"composer": {"laravel/framework": "11.0.0"}}
+
+		return env
+	}
+
+	tests := []struct {
+		name   string
+		change func(a, b *Environment)
+		want   []EnvironmentDifference
+	}{
+		{
+			name:   "identical",
+			change: func(_, _ *Environment) {},
+		},
+		{
+			name:   "tool missing in A",
+			change: func(a, _ *Environment) { delete(a.Tools, "node") },
+			want:   []EnvironmentDifference{{Section: "tools", Name: "node", B: "20.11.1"}},
+		},
+		{
+			name:   "tool missing in B",
+			change: func(_, b *Environment) { delete(b.Tools, "node") },
+			want:   []EnvironmentDifference{{Section: "tools", Name: "node", A: "20.11.1"}},
+		},
+		{
+			name:   "changed tool version",
+			change: func(_, b *Environment) { b.Tools["node"] = "22.1.0" },
+			want:   []EnvironmentDifference{{Section: "tools", Name: "node", A: "20.11.1", B: "22.1.0"}},
+		},
+		{
+			name: "changed dependency version",
+			change: func(_, b *Environment) {
+				b.Dependencies = map[string]map[string]string{"composer": {"laravel/framework": "11.1.0"}}
+			},
+			want: []EnvironmentDifference{{Section: "composer", Name: "laravel/framework", A: "11.0.0", B: "11.1.0"}},
+		},
+		{
+			name: "dependency manager missing in B",
+			change: func(_, b *Environment) {
+				b.Dependencies = map[string]map[string]string{}
+			},
+			want: []EnvironmentDifference{{Section: "composer", Name: "laravel/framework", A: "11.0.0"}},
+		},
+		{
+			name:   "extension added",
+			change: func(_, b *Environment) { b.PHP.Extensions = []string{"intl", "mbstring", "redis"} },
+			want:   []EnvironmentDifference{{Section: "extensions", Name: "redis", B: "loaded"}},
+		},
+		{
+			name:   "extension removed",
+			change: func(_, b *Environment) { b.PHP.Extensions = []string{"mbstring"} },
+			want:   []EnvironmentDifference{{Section: "extensions", Name: "intl", A: "loaded"}},
+		},
+		{
+			name: "PHP removed",
+			change: func(_, b *Environment) {
+				b.PHP = nil
+				delete(b.Tools, "php")
+			},
+			want: []EnvironmentDifference{
+				{Section: "tools", Name: "php", A: "8.3.4"},
+				{Section: "php", Name: "build date", A: "Mar 12 2024"},
+				{Section: "php", Name: "compiler", A: "GCC"},
+				{Section: "php", Name: "version", A: "8.3.4"},
+				{Section: "extensions", Name: "intl", A: "loaded"},
+				{Section: "extensions", Name: "mbstring", A: "loaded"},
+			},
+		},
+		{
+			name:   "other platform",
+			change: func(_, b *Environment) { b.OS = "darwin" },
+			want:   []EnvironmentDifference{{Section: "platform", Name: "os", A: "linux", B: "darwin"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, b := base(), base()
+			test.change(&a, &b)
+
+			got := DiffEnvironments(a, b)
+
+			if len(got) != len(test.want) {
+				t.Fatalf("DiffEnvironments() = %v, want %v", got, test.want)
+			}
+
+			for i := range test.want {
+				if got[i] != test.want[i] {
+					t.Errorf("difference %d = %v, want %v", i, got[i], test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadEnvironment(t *testing.T) {
+	dir := t.TempDir()
+
+	env := NewEnvironment("1.0.0")
+	env.Tools["go"] = "1.24.1"
+	path := filepath.Join(dir, "environment.json")
+
+	if err := WriteEnvironment(env, path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadEnvironment(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if version, err := loaded.ToolVersion("go"); err != nil || version != "1.24.1" {
+		t.Errorf("ToolVersion(go) = %q, %v, want 1.24.1", version, err)
+	}
+
+	if _, err := loaded.ToolVersion("node"); err == nil {
+		t.Error("ToolVersion(node) of a missing tool succeeded")
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "newer version", content: `{"version":2,"tools":{}}`, wantErr: "unsupported environment version 2"},
+		{name: "missing version", content: `{"tools":{}}`, wantErr: "unsupported environment version 0"},
+		{name: "invalid JSON", content: `{"version":`, wantErr: "invalid environment"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "environment.json")
+
+			if err := os.WriteFile(path, []byte(test.content), 0o644); err != nil { //nolint:gosec
+				t.Fatal(err)
+			}
+
+			if _, err := LoadEnvironment(path); err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("LoadEnvironment() error = %v, want it to contain %q", err, test.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadEnvironment(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("LoadEnvironment() of a missing file succeeded")
+	}
+}
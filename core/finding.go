@@ -0,0 +1,50 @@
+package core
+
+// Severity DEFINES HOW A FINDING AFFECTS THE OVERALL CHECK RESULT.
+type Severity string
+
+const (
+	// SeverityError MARKS A REQUIREMENT THAT IS NOT MET.
+	SeverityError Severity = "error"
+
+	// SeverityWarning MARKS A REQUIREMENT THAT IS MET BUT NEEDS ATTENTION.
+	SeverityWarning Severity = "warning"
+
+	// SeveritySuccess MARKS A REQUIREMENT THAT IS MET.
+	SeveritySuccess Severity = "success"
+)
+
+// Finding REPRESENTS A SINGLE RESULT PRODUCED BY A MODULE CHECK.
+type Finding struct {
+	// Severity OF THE FINDING.
+	Severity Severity `json:"severity"`
+
+	// Module THAT PRODUCED THE FINDING.
+	Module string `json:"module"`
+
+	// RuleID IS A STABLE IDENTIFIER OF THE REQUIREMENT THAT WAS CHECKED.
+	RuleID string `json:"ruleId"`
+
+	// Subject IS THE PACKAGE, EXTENSION OR TOOL THE FINDING IS ABOUT.
+	Subject string `json:"subject,omitempty"`
+
+	// Required VERSION CONSTRAINT, IF ANY.
+	Required string `json:"required,omitempty"`
+
+	// Installed VERSION, IF KNOWN.
+	Installed string `json:"installed,omitempty"`
+
+	// File IS THE MANIFEST THE REQUIREMENT WAS READ FROM.
+	File string `json:"file,omitempty"`
+
+	// Remediation IS A COMMAND THAT RESOLVES THE FINDING.
+	Remediation string `json:"remediation,omitempty"`
+
+	// Message IS A HUMAN-READABLE DESCRIPTION OF THE FINDING.
+	Message string `json:"message"`
+}
+
+// IsDependency REPORTS WHETHER THE FINDING IS ABOUT A SINGLE DECLARED DEPENDENCY.
+func (f Finding) IsDependency() bool {
+	return LookupRule(f.RuleID).Dependency
+}
@@ -0,0 +1,426 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// HistoryDir IS WHERE RUNS ARE RECORDED, RELATIVE TO THE PROJECT ROOT.
+const HistoryDir = ".preflight/history"
+
+// historyVersion IS INCREMENTED WHENEVER THE FORMAT OF A HISTORY ENTRY CHANGES.
+const historyVersion = 1
+
+// HistoryLimit IS THE NUMBER OF RUNS KEPT IN THE HISTORY, OLDER RUNS ARE DELETED WHEN A RUN IS RECORDED.
+const HistoryLimit = 500
+
+// historyFileLayout NAMES EVERY ENTRY AFTER THE START OF ITS RUN, SO THE FILE NAMES SORT CHRONOLOGICALLY.
+const historyFileLayout = "20060102T150405.000000000Z"
+
+// HistoryEntry IS THE RECORD OF A SINGLE preflight check RUN.
+type HistoryEntry struct {
+	Version    int              `json:"version"`
+	Tool       JSONTool         `json:"tool"`
+	StartedAt  time.Time        `json:"startedAt"`
+	DurationMs int64            `json:"durationMs"`
+	Status     Status           `json:"status"`
+	ExitCode   int              `json:"exitCode"`
+	Modules    []HistoryModule  `json:"modules"`
+	Findings   []HistoryFinding `json:"findings"`
+
+	// Selected LISTS THE MODULES THE RUN WAS LIMITED TO, SUCH AS BY --pm, BEFORE THE ECOSYSTEMS OF THE PROJECT WERE DETECTED.
+	// A MODULE THAT WAS NOT SELECTED WAS NOT CHECKED, SO ITS REQUIREMENTS ARE NOT COMPARED WITH OTHER RUNS.
+	Selected []string `json:"selected,omitempty"`
+
+	// Manifests MAPS EVERY MANIFEST AND LOCK FILE OF THE PROJECT TO THE HASH OF ITS CONTENT AT THE TIME OF THE RUN.
+	Manifests map[string]string `json:"manifests"`
+}
+
+// HistoryModule RECORDS THE OUTCOME OF A SINGLE MODULE.
+type HistoryModule struct {
+	Name       string `json:"name"`
+	Status     Status `json:"status"`
+	DurationMs int64  `json:"durationMs"`
+	Cached     bool   `json:"cached,omitempty"`
+}
+
+// HistoryFinding RECORDS A SINGLE FINDING, IDENTIFIED BY ITS MODULE, RULE ID AND SUBJECT.
+type HistoryFinding struct {
+	Module   string   `json:"module"`
+	RuleID   string   `json:"ruleId"`
+	Subject  string   `json:"subject,omitempty"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// Requirement RETURNS THE KEY OF THE REQUIREMENT THE FINDING IS ABOUT.
+func (f HistoryFinding) Requirement() string {
+	return f.Module + " " + f.RuleID + " " + f.Subject
+}
+
+// NewHistoryEntry RECORDS A REPORT OF THE PROJECT IN projectDir.
+func NewHistoryEntry(report CheckReport, projectDir string) HistoryEntry {
+	entry := HistoryEntry{
+		Version:    historyVersion,
+		Tool:       JSONTool{Name: "PreFlight", Version: report.Version},
+		StartedAt:  report.StartedAt,
+		DurationMs: report.EndedAt.Sub(report.StartedAt).Milliseconds(),
+		Status:     report.Status(),
+		ExitCode:   report.ExitCode(),
+		Modules:    make([]HistoryModule, 0, len(report.Results)),
+		Findings:   []HistoryFinding{},
+		Selected:   slices.Clone(report.Selected),
+		Manifests:  make(map[string]string),
+	}
+
+	for _, result := range report.Results {
+		entry.Modules = append(entry.Modules, HistoryModule{
+			Name:       result.Scope,
+			Status:     result.Status(),
+			DurationMs: result.Duration.Milliseconds(),
+			Cached:     result.Cached,
+		})
+
+		for _, finding := range result.Findings {
+			entry.Findings = append(entry.Findings, HistoryFinding{
+				Module:   result.Scope,
+				RuleID:   finding.RuleID,
+				Subject:  finding.Subject,
+				Severity: finding.Severity,
+				Message:  finding.Message,
+			})
+		}
+	}
+
+	for _, manifest := range historyManifests() {
+		if fingerprint := fileFingerprint(filepath.Join(projectDir, manifest)); fingerprint != "missing" {
+			entry.Manifests[manifest] = fingerprint
+		}
+	}
+
+	return entry
+}
+
+// historyManifests RETURNS THE MANIFESTS AND LOCK FILES WHOSE CHANGES ARE TRACKED BY THE HISTORY.
+func historyManifests() []string {
+	manifests := slices.Clone(ProjectManifests)
+
+	for _, packageType := range []string{"composer", "package"} {
+		manifests = append(manifests, utils.LockFiles(packageType)...)
+	}
+
+	return append(manifests, "go.sum")
+}
+
+// AppendHistory WRITES THE ENTRY TO THE HISTORY OF THE PROJECT IN projectDir, ONE FILE PER RUN.
+// ONLY THE LAST HistoryLimit RUNS ARE KEPT.
+func AppendHistory(projectDir string, entry HistoryEntry) error {
+	dir := filepath.Join(projectDir, HistoryDir)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gosec
+		return fmt.Errorf("unable to write history: %w", err)
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, entry.StartedAt.UTC().Format(historyFileLayout)+".json")
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("unable to write history: %w", err)
+	}
+
+	return pruneHistory(dir, HistoryLimit)
+}
+
+// pruneHistory DELETES THE OLDEST ENTRIES IN dir UNTIL AT MOST limit ARE LEFT.
+func pruneHistory(dir string, limit int) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+
+	if err != nil || len(files) <= limit {
+		return err
+	}
+
+	// THE FILE NAMES SORT CHRONOLOGICALLY, SEE historyFileLayout.
+	slices.Sort(files)
+
+	for _, file := range files[:len(files)-limit] {
+		if err := os.Remove(file); err != nil {
+			return fmt.Errorf("unable to prune history: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// LoadHistory READS ALL ENTRIES OF THE PROJECT IN projectDir, OLDEST FIRST.
+// ENTRIES THAT CANNOT BE READ OR WERE WRITTEN IN ANOTHER FORMAT ARE SKIPPED.
+func LoadHistory(projectDir string) ([]HistoryEntry, error) {
+	files, err := filepath.Glob(filepath.Join(projectDir, HistoryDir, "*.json"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]HistoryEntry, 0, len(files))
+
+	for _, file := range files {
+		data, err := os.ReadFile(file) //nolint:gosec
+
+		if err != nil {
+			continue
+		}
+
+		var entry HistoryEntry
+
+		if json.Unmarshal(data, &entry) != nil || entry.Version != historyVersion {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	slices.SortStableFunc(entries, func(a, b HistoryEntry) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+
+	return entries, nil
+}
+
+// REQUIREMENT EVENTS REPORTED BY AnalyzeHistory.
+const (
+	// EventIntroduced MEANS A REQUIREMENT WAS CHECKED FOR THE FIRST TIME.
+	EventIntroduced = "introduced"
+
+	// EventRemoved MEANS A REQUIREMENT IS NO LONGER CHECKED.
+	EventRemoved = "removed"
+
+	// EventFailing MEANS A REQUIREMENT STARTED FAILING, OR FAILS WITH ANOTHER SEVERITY.
+	EventFailing = "failing"
+
+	// EventFixed MEANS A FAILING REQUIREMENT PASSES AGAIN.
+	EventFixed = "fixed"
+)
+
+// HistoryTrends IS THE ANALYSIS OF A PROJECT HISTORY.
+type HistoryTrends struct {
+	Runs      []HistoryRun       `json:"runs"`
+	Events    []RequirementEvent `json:"events"`
+	Durations []ModuleDurations  `json:"durations"`
+}
+
+// HistoryRun SUMMARIZES A SINGLE RUN.
+type HistoryRun struct {
+	StartedAt  time.Time `json:"startedAt"`
+	DurationMs int64     `json:"durationMs"`
+	Status     Status    `json:"status"`
+	Errors     int       `json:"errors"`
+	Warnings   int       `json:"warnings"`
+
+	// ChangedManifests LISTS THE MANIFESTS AND LOCK FILES THAT CHANGED SINCE THE PREVIOUS RUN.
+	ChangedManifests []string `json:"changedManifests,omitempty"`
+}
+
+// RequirementEvent IS A CHANGE OF A SINGLE REQUIREMENT BETWEEN TWO CONSECUTIVE RUNS.
+type RequirementEvent struct {
+	At       time.Time `json:"at"`
+	Event    string    `json:"event"`
+	Module   string    `json:"module"`
+	RuleID   string    `json:"ruleId"`
+	Subject  string    `json:"subject,omitempty"`
+	Severity Severity  `json:"severity"`
+	Message  string    `json:"message"`
+
+	// Manifests LISTS THE MANIFESTS WHOSE CHANGE INTRODUCED OR REMOVED THE REQUIREMENT.
+	Manifests []string `json:"manifests,omitempty"`
+}
+
+// ModuleDurations HOLDS THE DURATIONS OF A MODULE ACROSS ALL RUNS THAT ACTUALLY RAN IT, OLDEST FIRST.
+type ModuleDurations struct {
+	Module      string  `json:"module"`
+	DurationsMs []int64 `json:"durationsMs"`
+}
+
+// AnalyzeHistory DETECTS WHEN REQUIREMENTS WERE INTRODUCED, STARTED OR STOPPED FAILING, AND HOW MODULE DURATIONS CHANGED.
+// A MODULE THAT TIMED OUT, WAS BLOCKED OR WAS NOT SELECTED KEEPS THE STATE OF ITS REQUIREMENTS FROM THE PREVIOUS RUN.
+func AnalyzeHistory(entries []HistoryEntry) HistoryTrends {
+	trends := HistoryTrends{
+		Runs:      make([]HistoryRun, 0, len(entries)),
+		Events:    []RequirementEvent{},
+		Durations: []ModuleDurations{},
+	}
+
+	durations := make(map[string]int)
+	state := make(map[string]HistoryFinding)
+
+	var previous *HistoryEntry
+
+	for i, entry := range entries {
+		run := HistoryRun{StartedAt: entry.StartedAt, DurationMs: entry.DurationMs, Status: entry.Status}
+
+		for _, finding := range entry.Findings {
+			switch finding.Severity {
+			case SeverityError:
+				run.Errors++
+			case SeverityWarning:
+				run.Warnings++
+			}
+		}
+
+		if previous != nil {
+			run.ChangedManifests = changedManifests(previous.Manifests, entry.Manifests)
+		}
+
+		trends.Runs = append(trends.Runs, run)
+
+		// ONLY MODULES THAT COMPLETED TELL WHICH REQUIREMENTS WERE CHECKED.
+		completed := make(map[string]bool, len(entry.Modules))
+		selected := make(map[string]bool, len(entry.Selected))
+
+		for _, name := range entry.Selected {
+			selected[name] = true
+		}
+
+		for _, module := range entry.Modules {
+			completed[module.Name] = module.Status != StatusTimedOut && module.Status != StatusBlocked
+
+			if module.Cached || module.Status == StatusBlocked {
+				continue
+			}
+
+			index, ok := durations[module.Name]
+
+			if !ok {
+				index = len(trends.Durations)
+				durations[module.Name] = index
+				trends.Durations = append(trends.Durations, ModuleDurations{Module: module.Name})
+			}
+
+			trends.Durations[index].DurationsMs = append(trends.Durations[index].DurationsMs, module.DurationMs)
+		}
+
+		current := make(map[string]HistoryFinding, len(entry.Findings))
+
+		for _, finding := range entry.Findings {
+			if completed[finding.Module] {
+				current[finding.Requirement()] = finding
+			}
+		}
+
+		event := func(name string, finding HistoryFinding) {
+			requirementEvent := RequirementEvent{
+				At:       entry.StartedAt,
+				Event:    name,
+				Module:   finding.Module,
+				RuleID:   finding.RuleID,
+				Subject:  finding.Subject,
+				Severity: finding.Severity,
+				Message:  finding.Message,
+			}
+
+			if name == EventIntroduced || name == EventRemoved {
+				requirementEvent.Manifests = run.ChangedManifests
+			}
+
+			trends.Events = append(trends.Events, requirementEvent)
+		}
+
+		// THE FIRST RUN IS THE STARTING POINT, ONLY ITS FAILURES ARE EVENTS.
+		for _, key := range sortedKeys(current) {
+			finding := current[key]
+			before, known := state[key]
+
+			switch {
+			case !known && i > 0:
+				event(EventIntroduced, finding)
+
+				if isFailing(finding.Severity) {
+					event(EventFailing, finding)
+				}
+			case !known && isFailing(finding.Severity):
+				event(EventFailing, finding)
+			case known && isFailing(finding.Severity) && finding.Severity != before.Severity:
+				event(EventFailing, finding)
+			case known && isFailing(before.Severity) && !isFailing(finding.Severity):
+				event(EventFixed, finding)
+			}
+
+			state[key] = finding
+		}
+
+		// REQUIREMENTS OF A COMPLETED MODULE THAT WERE NOT CHECKED AGAIN WERE REMOVED.
+		for _, key := range sortedKeys(state) {
+			finding := state[key]
+
+			if _, ok := current[key]; ok || !completedOrGone(completed, selected, finding.Module) {
+				continue
+			}
+
+			event(EventRemoved, finding)
+			delete(state, key)
+		}
+
+		previous = &entries[i]
+	}
+
+	return trends
+}
+
+// completedOrGone REPORTS WHETHER A MODULE COMPLETED IN A RUN, OR WAS SELECTED BUT DID NOT RUN BECAUSE IT NO LONGER APPLIES.
+// ENTRIES RECORDED WITHOUT A SELECTION SELECTED EVERY MODULE.
+func completedOrGone(completed, selected map[string]bool, module string) bool {
+	if done, ran := completed[module]; ran {
+		return done
+	}
+
+	return len(selected) == 0 || selected[module]
+}
+
+// isFailing REPORTS WHETHER A FINDING OF THE GIVEN SEVERITY NEEDS ATTENTION.
+func isFailing(severity Severity) bool {
+	return severity == SeverityError || severity == SeverityWarning
+}
+
+// changedManifests RETURNS THE MANIFESTS THAT WERE ADDED, REMOVED OR MODIFIED BETWEEN TWO RUNS, SORTED BY NAME.
+func changedManifests(before, after map[string]string) []string {
+	var changed []string
+
+	for name, fingerprint := range after {
+		if before[name] != fingerprint {
+			changed = append(changed, name)
+		}
+	}
+
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+
+	slices.Sort(changed)
+
+	return changed
+}
+
+// sortedKeys RETURNS THE UNIQUE KEYS OF THE MAPS IN ORDER, SO EVENTS AND DIFFERENCES ARE REPORTED DETERMINISTICALLY.
+func sortedKeys[V any](maps ...map[string]V) []string {
+	var keys []string
+
+	for _, m := range maps {
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	return slices.Compact(keys)
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeHistoryPartialRuns(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	php := HistoryFinding{Module: "PHP", RuleID: RulePHPExtension, Subject: "ext-intl", Severity: SeverityError}
+	goVersion := HistoryFinding{Module: "Go", RuleID: RuleGoVersion, Subject: "go", Severity: SeveritySuccess}
+
+	full := func(at int, findings ...HistoryFinding) HistoryEntry {
+		return HistoryEntry{
+			StartedAt: start.Add(time.Duration(at) * time.Hour),
+			Selected:  []string{"PHP", "Go"},
+			Modules:   []HistoryModule{{Name: "PHP", Status: StatusError}, {Name: "Go", Status: StatusSuccess}},
+			Findings:  findings,
+		}
+	}
+
+	partial := HistoryEntry{
+		StartedAt: start.Add(time.Hour),
+		Selected:  []string{"Go"},
+		Modules:   []HistoryModule{{Name: "Go", Status: StatusSuccess}},
+		Findings:  []HistoryFinding{goVersion},
+	}
+
+	// PHP WAS NOT SELECTED BY THE PARTIAL RUN, SO ITS FAILURE IS NEITHER REMOVED NOR INTRODUCED AGAIN.
+	trends := AnalyzeHistory([]HistoryEntry{full(0, php, goVersion), partial, full(2, php, goVersion)})
+
+	if len(trends.Events) != 1 || trends.Events[0].Event != EventFailing || trends.Events[0].Module != "PHP" {
+		t.Errorf("Events = %+v, want only the initial failure of PHP", trends.Events)
+	}
+
+	// A SELECTED MODULE THAT NO LONGER RUNS NO LONGER APPLIES, SO ITS REQUIREMENTS ARE REMOVED.
+	gone := full(3, goVersion)
+	gone.Modules = gone.Modules[1:]
+	trends = AnalyzeHistory([]HistoryEntry{full(0, php, goVersion), gone})
+
+	if last := trends.Events[len(trends.Events)-1]; last.Event != EventRemoved || last.Module != "PHP" {
+		t.Errorf("Events = %+v, want PHP to be removed", trends.Events)
+	}
+}
+
+func TestPruneHistory(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := range 5 {
+		name := start.Add(time.Duration(i)*time.Minute).Format(historyFileLayout) + ".json"
+
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}\n"), 0o644); err != nil { //nolint:gosec
+			t.Fatal(err)
+		}
+	}
+
+	if err := pruneHistory(dir, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+
+	if len(files) != 3 || filepath.Base(files[0]) != start.Add(2*time.Minute).Format(historyFileLayout)+".json" {
+		t.Errorf("files = %v, want the 3 newest", files)
+	}
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// lineLocator FINDS THE FILE AND LINE A FINDING REFERS TO WITHIN ITS MANIFEST.
+type lineLocator struct {
+	files map[string][]string
+	roots map[string]string
+}
+
+// newLineLocator CREATES A lineLocator WITH AN EMPTY FILE CACHE.
+func newLineLocator() *lineLocator {
+	return &lineLocator{files: make(map[string][]string), roots: make(map[string]string)}
+}
+
+// Path RETURNS THE FILE OF THE FINDING RELATIVE TO THE ROOT OF ITS REPOSITORY, WITH FORWARD SLASHES,
+// SO CODE SCANNING AND CI ANNOTATIONS CAN PLACE IT REGARDLESS OF --dir OR --recursive.
+// FILES OUTSIDE A REPOSITORY ARE RELATIVE TO THE WORKING DIRECTORY.
+func (l *lineLocator) Path(finding Finding) string {
+	if finding.File == "" {
+		return ""
+	}
+
+	path, err := filepath.Abs(finding.File)
+
+	if err != nil {
+		return filepath.ToSlash(finding.File)
+	}
+
+	dir := filepath.Dir(path)
+	root, ok := l.roots[dir]
+
+	if !ok {
+		root = repositoryRoot(dir)
+		l.roots[dir] = root
+	}
+
+	if rel, err := filepath.Rel(root, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(rel)
+	}
+
+	return filepath.ToSlash(path)
+}
+
+// repositoryRoot RETURNS THE CLOSEST DIRECTORY CONTAINING dir THAT HOLDS A .git ENTRY, OR THE WORKING DIRECTORY.
+func repositoryRoot(dir string) string {
+	for current := dir; ; current = filepath.Dir(current) {
+		if _, err := utils.Stat(filepath.Join(current, ".git")); err == nil {
+			return current
+		}
+
+		if filepath.Dir(current) == current {
+			break
+		}
+	}
+
+	wd, _ := os.Getwd()
+
+	return wd
+}
+
+// Line RETURNS THE 1-BASED LINE OF THE FINDING SUBJECT IN ITS FILE, OR 0 WHEN UNKNOWN.
+func (l *lineLocator) Line(finding Finding) int {
+	if finding.File == "" || finding.Subject == "" {
+		return 0
+	}
+
+	lines, ok := l.files[finding.File]
+
+	if !ok {
+		data, err := utils.ReadFile(finding.File)
+
+		if err == nil {
+			lines = strings.Split(string(data), "\n")
+		}
+
+		l.files[finding.File] = lines
+	}
+
+	quoted := `"` + finding.Subject + `"`
+
+	for i, line := range lines {
+		// JSON MANIFESTS QUOTE KEYS, go.mod LISTS MODULES AS PLAIN FIELDS.
+		if strings.Contains(line, quoted) {
+			return i + 1
+		}
+
+		for _, field := range strings.Fields(line) {
+			if field == finding.Subject {
+				return i + 1
+			}
+		}
+	}
+
+	return 0
+}
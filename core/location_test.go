@@ -0,0 +1,38 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLineLocatorPath(t *testing.T) {
+	repository := t.TempDir()
+	project := filepath.Join(repository, "services", "api")
+
+	if err := os.MkdirAll(filepath.Join(repository, ".git"), 0o755); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(project, 0o755); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		file string
+		want string
+	}{
+		{name: "no file", file: "", want: ""},
+		{name: "absolute path in a repository", file: filepath.Join(project, "composer.json"), want: "services/api/composer.json"},
+		{name: "repository root", file: filepath.Join(repository, "go.mod"), want: "go.mod"},
+	}
+
+	locator := newLineLocator()
+
+	for _, test := range tests {
+		if got := locator.Path(Finding{File: test.file}); got != test.want {
+			t.Errorf("%s: Path() = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
@@ -11,19 +11,70 @@ import (
 // Module DEFINES THE CONTRACT FOR SYSTEM CHECK MODULES.
 type Module interface {
 	Name() string
-	CheckRequirements(ctx context.Context) (errors []string, warnings []string, successes []string)
+	CheckRequirements(ctx context.Context) []Finding
 }
 
-var (
-	// modulesMutex PROTECTS registeredModules AND availableModules FROM CONCURRENT MODIFICATIONS.
-	modulesMutex sync.RWMutex
+// RequirementLister IS IMPLEMENTED BY MODULES THAT CAN LIST THE SUBJECTS THEY VERIFY WITHOUT RUNNING ANY COMMAND.
+// IT IS USED TO REPORT WHICH REQUIREMENTS WERE NOT VERIFIED WHEN A MODULE TIMES OUT.
+type RequirementLister interface {
+	Requirements(ctx context.Context) []string
+}
 
-	// registeredModules CONTAINS THE ACTIVE MODULES.
-	registeredModules = make(map[string]Module)
+// DependentModule IS IMPLEMENTED BY MODULES THAT ARE ONLY MEANINGFUL WHEN OTHER MODULES PASS.
+// DependsOn RETURNS THE NAMES OF THOSE PREREQUISITES, PREREQUISITES THAT ARE NOT REGISTERED ARE IGNORED.
+type DependentModule interface {
+	DependsOn() []string
+}
 
-	// availableModules CONTAINS ALL KNOWN MODULES THAT ARE REGISTERED.
-	availableModules = make(map[string]Module)
-)
+// Detector IS IMPLEMENTED BY MODULES THAT ONLY APPLY TO PROJECTS OF A SPECIFIC ECOSYSTEM.
+// Detect REPORTS WHETHER THE PROJECT IN projectRoot BELONGS TO IT AND WHY, MODULES THAT ARE NOT DETECTED ARE NOT RUN.
+// AN EMPTY projectRoot MEANS THE WORKING DIRECTORY.
+type Detector interface {
+	Detect(projectRoot string) (detected bool, reason string)
+}
+
+// Detection RECORDS THE OUTCOME OF Detector.Detect FOR A SINGLE MODULE.
+type Detection struct {
+	Module   string `json:"module"`
+	Detected bool   `json:"detected"`
+	Reason   string `json:"reason"`
+}
+
+// WatchedModule IS IMPLEMENTED BY MODULES WHOSE RESULT ONLY DEPENDS ON SPECIFIC FILES OR DIRECTORIES OF THE PROJECT.
+// WatchedFiles RETURNS THEIR NAMES RELATIVE TO THE PROJECT ROOT, WATCH MODE ONLY RE-RUNS THE MODULE WHEN ONE OF THEM CHANGES.
+// MODULES WITHOUT IT ARE RE-RUN ON EVERY CHANGE.
+type WatchedModule interface {
+	WatchedFiles() []string
+}
+
+// detectModules SPLITS OFF THE MODULES WHOSE ECOSYSTEM WAS NOT DETECTED IN projectRoot.
+// MODULES WITHOUT A Detector ALWAYS APPLY, UNLESS ecosystemsOnly IS SET, AND ARE NOT PART OF THE DETECTIONS.
+func detectModules(modules []Module, projectRoot string, ecosystemsOnly bool) ([]Module, []Detection) {
+	detected := make([]Module, 0, len(modules))
+
+	var detections []Detection
+
+	for _, module := range modules {
+		detector, ok := module.(Detector)
+
+		if !ok {
+			if !ecosystemsOnly {
+				detected = append(detected, module)
+			}
+
+			continue
+		}
+
+		applies, reason := detector.Detect(projectRoot)
+		detections = append(detections, Detection{Module: module.Name(), Detected: applies, Reason: reason})
+
+		if applies {
+			detected = append(detected, module)
+		}
+	}
+
+	return detected, detections
+}
 
 // SortType DEFINES THE SORTING METHOD TO BE APPLIED TO MODULES.
 type SortType string
@@ -36,114 +87,135 @@ const (
 	SortByName SortType = "name"
 )
 
+// ParseSortType VALIDATES A SORT TYPE, AN EMPTY VALUE DEFAULTS TO SortByPriority.
+func ParseSortType(value string) (SortType, error) {
+	switch sortType := SortType(strings.ToLower(strings.TrimSpace(value))); sortType {
+	case "":
+		return SortByPriority, nil
+	case SortByPriority, SortByName:
+		return sortType, nil
+	default:
+		return "", fmt.Errorf("unknown sort type '%s', expected priority or name", value)
+	}
+}
+
 var defaultPriority = map[string]int{
 	"php":      1,
 	"composer": 2,
 	"node":     3,
-	"bun":      4,
-	"yarn":     5,
-	"pnpm":     6,
-	"npm":      7,
+	"package":  4,
+	"bun":      5,
+	"yarn":     6,
+	"pnpm":     7,
+	"npm":      8,
+	"go":       9,
 }
 
 const fallbackPriority = 1000
 
-// RegisterModule REGISTERS A NEW MODULE IF IT DOESN'T ALREADY EXIST.
-func RegisterModule(module Module, moduleNames ...string) error {
-	modulesMutex.Lock()
-	defer modulesMutex.Unlock()
+// Registry HOLDS THE MODULES AVAILABLE TO A RUN BY THEIR LOWERCASE NAME.
+// EVERY RUN USES ITS OWN REGISTRY, SO SEVERAL CHECKS CAN RUN IN ONE PROCESS.
+type Registry struct {
+	// mutex PROTECTS modules FROM CONCURRENT MODIFICATIONS.
+	mutex sync.RWMutex
+
+	// modules CONTAINS ALL AVAILABLE MODULES.
+	modules map[string]Module
+}
+
+// NewRegistry CREATES AN EMPTY REGISTRY.
+func NewRegistry() *Registry {
+	return &Registry{modules: make(map[string]Module)}
+}
 
-	if module != nil {
-		return registerSingleModule(module)
+// Register ADDS A MODULE UNDER THE GIVEN NAME, OR UNDER ITS OWN NAME WHEN THE NAME IS EMPTY.
+func (r *Registry) Register(name string, module Module) error {
+	if module == nil {
+		return fmt.Errorf("module '%s' is nil", name)
 	}
 
-	if len(moduleNames) == 0 {
-		return registerAllModules()
+	if name == "" {
+		name = module.Name()
 	}
 
-	return registerSpecificModules(moduleNames)
-}
+	name = strings.ToLower(strings.TrimSpace(name))
 
-// registerSingleModule HELPER FUNCTION TO REGISTER A SINGLE MODULE.
-func registerSingleModule(module Module) error {
-	if _, exists := registeredModules[module.Name()]; exists {
-		return fmt.Errorf("module with name '%s' is already registered", module.Name())
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.modules[name]; exists {
+		return fmt.Errorf("module with name '%s' is already registered", name)
 	}
 
-	registeredModules[module.Name()] = module
+	r.modules[name] = module
+
 	return nil
 }
 
-// registerAllModules HELPER FUNCTION TO REGISTER ALL AVAILABLE MODULES.
-func registerAllModules() error {
-	var errs []string
+// Lookup RETURNS THE MODULE REGISTERED UNDER A NAME.
+func (r *Registry) Lookup(name string) (Module, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
 
-	for name, mod := range availableModules {
-		if _, exists := registeredModules[name]; !exists {
-			registeredModules[name] = mod
-		} else {
-			errs = append(errs, fmt.Sprintf("module '%s' is already registered", name))
-		}
-	}
+	module, exists := r.modules[strings.ToLower(strings.TrimSpace(name))]
 
-	if len(errs) > 0 {
-		return fmt.Errorf("error registering modules: %s", strings.Join(errs, "; "))
+	return module, exists
+}
+
+// Names RETURNS THE SORTED NAMES OF ALL REGISTERED MODULES.
+func (r *Registry) Names() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	names := make([]string, 0, len(r.modules))
+
+	for name := range r.modules {
+		names = append(names, name)
 	}
 
-	return nil
+	sort.Strings(names)
+
+	return names
 }
 
-// registerSpecificModules HELPER FUNCTION TO REGISTER SPECIFIC MODULES BY NAME.
-func registerSpecificModules(moduleNames []string) error {
+// Select RETURNS THE MODULES WITH THE GIVEN NAMES, OR ALL MODULES WHEN NO NAME IS GIVEN.
+// THE REGISTRY IS NOT MODIFIED, SO IT CAN BE SHARED BY SEVERAL RUNS.
+func (r *Registry) Select(names ...string) ([]Module, error) {
+	if len(names) == 0 {
+		names = r.Names()
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
 	var errs []string
 
-	for _, name := range moduleNames {
-		mod, exists := availableModules[name]
+	modules := make([]Module, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
 
-		if !exists {
-			errs = append(errs, fmt.Sprintf("unknown module: %s", name))
-			continue
-		}
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
 
-		if _, registered := registeredModules[name]; registered {
-			errs = append(errs, fmt.Sprintf("module with name '%s' is already registered", name))
+		if _, ok := seen[name]; ok {
 			continue
 		}
 
-		registeredModules[name] = mod
-	}
-
-	if len(errs) > 0 {
-		return fmt.Errorf("error registering modules: %s", strings.Join(errs, "; "))
-	}
+		seen[name] = struct{}{}
+		module, exists := r.modules[name]
 
-	return nil
-}
-
-// GetModules RETURNS A COPY OF THE REGISTERED MODULES.
-func GetModules() []Module {
-	modulesMutex.RLock()
-	defer modulesMutex.RUnlock()
-
-	modules := make([]Module, 0, len(registeredModules))
+		if !exists {
+			errs = append(errs, fmt.Sprintf("unknown module: %s", name))
+			continue
+		}
 
-	for _, module := range registeredModules {
 		modules = append(modules, module)
 	}
 
-	return modules
-}
-
-// RegisterAvailableModule ADDS A MODULE TO THE LIST OF AVAILABLE MODULES.
-func RegisterAvailableModule(name string, module Module) {
-	if module == nil {
-		return
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("error selecting modules: %s", strings.Join(errs, "; "))
 	}
 
-	modulesMutex.Lock()
-	defer modulesMutex.Unlock()
-
-	availableModules[name] = module
+	return modules, nil
 }
 
 // SortModules SORTS MODULES BASED ON THE SPECIFIED SORT TYPE.
@@ -163,14 +235,46 @@ func SortModules(modules []Module, sortType ...SortType) []Module {
 			return sortedModules[i].Name() < sortedModules[j].Name()
 		})
 	default:
+		// MODULES WITHOUT A PREDEFINED PRIORITY, SUCH AS CUSTOM MODULES, FALL BACK TO THEIR NAME.
 		sort.SliceStable(sortedModules, func(i, j int) bool {
-			return getPriority(sortedModules[i].Name()) < getPriority(sortedModules[j].Name())
+			pi, pj := getPriority(sortedModules[i].Name()), getPriority(sortedModules[j].Name())
+
+			if pi != pj {
+				return pi < pj
+			}
+
+			return sortedModules[i].Name() < sortedModules[j].Name()
 		})
 	}
 
 	return sortedModules
 }
 
+// OrderModules MOVES THE MODULES LISTED IN order TO THE FRONT, IN THAT ORDER, KEEPING THE REST AS SORTED.
+func OrderModules(modules []Module, order []string) []Module {
+	position := make(map[string]int, len(order))
+
+	for i, name := range order {
+		position[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	orderedModules := make([]Module, len(modules))
+	copy(orderedModules, modules)
+
+	sort.SliceStable(orderedModules, func(i, j int) bool {
+		pi, iListed := position[strings.ToLower(orderedModules[i].Name())]
+		pj, jListed := position[strings.ToLower(orderedModules[j].Name())]
+
+		if iListed && jListed {
+			return pi < pj
+		}
+
+		return iListed && !jListed
+	})
+
+	return orderedModules
+}
+
 func getPriority(name string) int {
 	if p, ok := defaultPriority[strings.ToLower(name)]; ok {
 		return p
@@ -178,3 +282,72 @@ func getPriority(name string) int {
 
 	return fallbackPriority
 }
+
+// buildDependencyGraph RETURNS THE INDEXES OF THE REGISTERED PREREQUISITES OF EACH MODULE.
+// IT FAILS WHEN THE DEPENDENCIES FORM A CYCLE, SINCE SUCH MODULES COULD NEVER START.
+func buildDependencyGraph(modules []Module) ([][]int, error) {
+	index := make(map[string]int, len(modules))
+
+	for i, module := range modules {
+		index[strings.ToLower(module.Name())] = i
+	}
+
+	prerequisites := make([][]int, len(modules))
+
+	for i, module := range modules {
+		dependent, ok := module.(DependentModule)
+
+		if !ok {
+			continue
+		}
+
+		for _, name := range dependent.DependsOn() {
+			if p, registered := index[strings.ToLower(name)]; registered && p != i {
+				prerequisites[i] = append(prerequisites[i], p)
+			}
+		}
+	}
+
+	// DEPTH-FIRST SEARCH, A MODULE THAT IS REACHED AGAIN WHILE STILL BEING VISITED CLOSES A CYCLE.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make([]int, len(modules))
+	var path []string
+
+	var visit func(i int) error
+
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("module dependency cycle: %s -> %s", strings.Join(path, " -> "), modules[i].Name())
+		}
+
+		state[i] = visiting
+		path = append(path, modules[i].Name())
+
+		for _, p := range prerequisites[i] {
+			if err := visit(p); err != nil {
+				return err
+			}
+		}
+
+		state[i] = visited
+		path = path[:len(path)-1]
+
+		return nil
+	}
+
+	for i := range modules {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return prerequisites, nil
+}
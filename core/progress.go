@@ -0,0 +1,164 @@
+package core
+
+import (
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"strings"
+	"sync"
+	"time"
+)
+
+// THE STATES OF A ProgressEvent.
+const (
+	ProgressRunning       = "running"
+	ProgressCompleted     = "completed"
+	ProgressNotApplicable = "not-applicable"
+	ProgressSkipped       = "skipped"
+)
+
+// ProgressEvent DESCRIBES A MODULE THAT STARTED, COMPLETED OR WAS SKIPPED DURING A RUN, SEE RunnerOptions.OnProgress.
+type ProgressEvent struct {
+	Module     string   `json:"module"`
+	State      string   `json:"state"`
+	Status     Status   `json:"status,omitempty"`
+	DurationMs int64    `json:"durationMs"`
+	Cached     bool     `json:"cached,omitempty"`
+	BlockedBy  []string `json:"blockedBy,omitempty"`
+	Reason     string   `json:"reason,omitempty"`
+}
+
+// progressPrinter SHOWS THE STATE OF CONCURRENTLY RUNNING MODULES.
+// ON A TERMINAL ONE LINE PER MODULE IS REDRAWN IN PLACE, OTHERWISE A LINE IS APPENDED WHEN A MODULE COMPLETES.
+type progressPrinter struct {
+	mu    sync.Mutex
+	ow    *utils.OutputWriter
+	live  bool
+	names []string
+	lines []string
+	drawn bool
+
+	// onProgress RECEIVES EVERY CHANGE OF A MODULE, IT IS CALLED IN ORDER WHEN NOT NIL.
+	onProgress func(ProgressEvent)
+}
+
+// newProgressPrinter CREATES A progressPrinter FOR THE GIVEN MODULE NAMES IN DISPLAY ORDER.
+func newProgressPrinter(ow *utils.OutputWriter, live bool, names []string, onProgress func(ProgressEvent)) *progressPrinter {
+	p := &progressPrinter{
+		ow:         ow,
+		live:       live,
+		names:      names,
+		lines:      make([]string, len(names)),
+		onProgress: onProgress,
+	}
+
+	for i, name := range names {
+		p.lines[i] = fmt.Sprintf("  %s %s", utils.Dim+"·"+utils.Reset, utils.Dim+name+" waiting"+utils.Reset)
+	}
+
+	p.redraw()
+
+	return p
+}
+
+// Start MARKS THE MODULE AT THE GIVEN INDEX AS RUNNING.
+func (p *progressPrinter) Start(index int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lines[index] = fmt.Sprintf("  %s %s %s", utils.Yellow+utils.TimeGlass+utils.Reset, utils.Bold+p.names[index]+utils.Reset, utils.Yellow+"..."+utils.Reset)
+	p.redraw()
+	p.notify(ProgressEvent{Module: p.names[index], State: ProgressRunning})
+}
+
+// Done MARKS THE MODULE AT THE GIVEN INDEX AS COMPLETED, A NIL RESULT MEANS THE MODULE DID NOT APPLY.
+func (p *progressPrinter) Done(index int, result *CheckResult, duration time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	name := utils.Bold + p.names[index] + utils.Reset
+
+	if result == nil {
+		p.lines[index] = fmt.Sprintf("  %s %s", utils.Dim+"-"+utils.Reset, utils.Dim+p.names[index]+" not applicable"+utils.Reset)
+		p.redraw()
+		p.notify(ProgressEvent{Module: p.names[index], State: ProgressNotApplicable, DurationMs: duration.Milliseconds()})
+		return
+	}
+
+	completed := fmt.Sprintf("completed (%dms)", duration.Milliseconds())
+
+	if result.Cached {
+		completed = "completed (cached)"
+	}
+
+	switch result.Status() {
+	case StatusTimedOut:
+		p.lines[index] = fmt.Sprintf("  %s %s timed out (%dms)", utils.Red+utils.TimeGlass+utils.Reset, name, duration.Milliseconds())
+	case StatusBlocked:
+		p.lines[index] = fmt.Sprintf("  %s %s blocked by %s", utils.Red+utils.CrossMark+utils.Reset, name, strings.Join(result.BlockedBy, ", "))
+	case StatusError:
+		p.lines[index] = fmt.Sprintf("  %s %s %s", utils.Red+utils.CrossMark+utils.Reset, name, completed)
+	case StatusWarning:
+		p.lines[index] = fmt.Sprintf("  %s %s %s", utils.Yellow+utils.WarningSign+utils.Reset, name, completed)
+	default:
+		p.lines[index] = fmt.Sprintf("  %s %s %s", utils.Green+utils.CheckMark+utils.Reset, name, completed)
+	}
+
+	p.flushLine(index)
+	p.notify(ProgressEvent{
+		Module:     p.names[index],
+		State:      ProgressCompleted,
+		Status:     result.Status(),
+		DurationMs: duration.Milliseconds(),
+		Cached:     result.Cached,
+		BlockedBy:  result.BlockedBy,
+	})
+}
+
+// Skip MARKS THE MODULE AT THE GIVEN INDEX AS NOT STARTED.
+func (p *progressPrinter) Skip(index int, reason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lines[index] = fmt.Sprintf("  %s %s", utils.Red+utils.CrossMark+utils.Reset, utils.Bold+p.names[index]+utils.Reset+" "+reason)
+	p.flushLine(index)
+	p.notify(ProgressEvent{Module: p.names[index], State: ProgressSkipped, Reason: reason})
+}
+
+// notify PASSES AN EVENT TO onProgress, THE CALLER HOLDS THE LOCK SO EVENTS ARRIVE IN ORDER.
+func (p *progressPrinter) notify(event ProgressEvent) {
+	if p.onProgress != nil {
+		p.onProgress(event)
+	}
+}
+
+// flushLine REDRAWS ALL LINES ON A TERMINAL, OTHERWISE IT APPENDS THE CHANGED LINE.
+func (p *progressPrinter) flushLine(index int) {
+	if p.live {
+		p.redraw()
+		return
+	}
+
+	p.ow.Println(p.lines[index])
+}
+
+// redraw REPRINTS ALL MODULE LINES IN PLACE, IT IS A NO-OP WHEN NOT WRITING TO A TERMINAL.
+func (p *progressPrinter) redraw() {
+	if !p.live {
+		return
+	}
+
+	var sb strings.Builder
+
+	if p.drawn {
+		fmt.Fprintf(&sb, "\033[%dA", len(p.lines))
+	}
+
+	for _, line := range p.lines {
+		sb.WriteString("\r\033[2K")
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	p.ow.Printf("%s", sb.String())
+	p.drawn = true
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ProjectManifests MARK A DIRECTORY AS A PROJECT OF ITS OWN FOR RECURSIVE CHECKS.
+var ProjectManifests = []string{"composer.json", "package.json", "go.mod"}
+
+// alwaysExcluded DIRECTORIES HOLD DEPENDENCIES, METADATA OR CACHES, THE MANIFESTS INSIDE THEM ARE NEVER PROJECTS.
+var alwaysExcluded = []string{".git/", ".preflight/", "node_modules/", "vendor/"}
+
+// DiscoverProjects WALKS root AND RETURNS EVERY DIRECTORY CONTAINING ONE OF ProjectManifests, RELATIVE TO root.
+// DIRECTORIES IGNORED BY A .gitignore OR MATCHING ONE OF THE .gitignore-STYLE exclude PATTERNS ARE SKIPPED.
+func DiscoverProjects(root string, exclude []string) ([]string, error) {
+	if root == "" {
+		root = "."
+	}
+
+	rules := &utils.IgnoreRules{}
+	rules.AddPatterns("", alwaysExcluded)
+	rules.AddPatterns("", exclude)
+
+	var projects []string
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// AN UNREADABLE SUBDIRECTORY IS SKIPPED, ONLY AN UNREADABLE ROOT FAILS THE DISCOVERY.
+			if path == root {
+				return err
+			}
+
+			return nil
+		}
+
+		if !entry.IsDir() {
+			return nil
+		}
+
+		relative, err := filepath.Rel(root, path)
+
+		if err != nil {
+			return err
+		}
+
+		relative = filepath.ToSlash(relative)
+		base := relative
+
+		if relative == "." {
+			base = ""
+		} else if rules.Ignored(relative, true) {
+			return filepath.SkipDir
+		}
+
+		// THE .gitignore OF A DIRECTORY APPLIES TO EVERYTHING BELOW IT.
+		if err := rules.AddFile(base, filepath.Join(path, ".gitignore")); err != nil {
+			return err
+		}
+
+		for _, manifest := range ProjectManifests {
+			if _, err := os.Stat(filepath.Join(path, manifest)); err == nil {
+				projects = append(projects, relative)
+				break
+			}
+		}
+
+		return nil
+	})
+
+	return projects, err
+}
+
+// ProjectReport IS THE REPORT OF A SINGLE PROJECT OF A RECURSIVE CHECK.
+type ProjectReport struct {
+	// Dir IS THE PROJECT DIRECTORY RELATIVE TO THE ROOT, "." FOR THE ROOT ITSELF.
+	Dir string
+
+	CheckReport
+}
+
+// MergeProjectReports COMBINES THE PROJECT REPORTS INTO ONE REPORT, PREFIXING EVERY SCOPE WITH ITS PROJECT.
+// ITS STATUS AND EXIT CODE ARE THE AGGREGATE OF ALL PROJECTS.
+func MergeProjectReports(projects []ProjectReport) CheckReport {
+	var merged CheckReport
+
+	for i, project := range projects {
+		if i == 0 || project.StartedAt.Before(merged.StartedAt) {
+			merged.StartedAt = project.StartedAt
+		}
+
+		if project.EndedAt.After(merged.EndedAt) {
+			merged.EndedAt = project.EndedAt
+		}
+
+		merged.Version = project.Version
+		merged.FailOn = project.FailOn
+		merged.Canceled = merged.Canceled || project.Canceled
+
+		for _, result := range project.Results {
+			result.Scope = projectScope(project.Dir, result.Scope)
+			merged.Results = append(merged.Results, result)
+		}
+
+		for _, detection := range project.Detections {
+			detection.Module = projectScope(project.Dir, detection.Module)
+			merged.Detections = append(merged.Detections, detection)
+		}
+	}
+
+	return merged
+}
+
+// projectScope PREFIXES A SCOPE WITH ITS PROJECT DIRECTORY, SCOPES OF THE ROOT ARE KEPT AS THEY ARE.
+func projectScope(dir, scope string) string {
+	if dir == "." || dir == "" {
+		return scope
+	}
+
+	return dir + ": " + scope
+}
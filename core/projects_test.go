@@ -0,0 +1,55 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestDiscoverProjects(t *testing.T) {
+	root := t.TempDir()
+
+	files := map[string]string{
+		"composer.json":         "{}",
+		".gitignore":            "/generated/\n",
+		"apps/web/package.json": "{}",
+		"apps/web/node_modules/left-pad/package.json": "{}",
+		"apps/web/.gitignore":                         "storybook-static\n",
+		"apps/web/storybook-static/package.json":      "{}",
+		"services/api/go.mod":                         "module api\n",
+		"services/api/vendor/example.com/lib/go.mod":  "module lib\n",
+		"vendor/laravel/framework/composer.json":      "{}",
+		"generated/client/package.json":               "{}",
+		"examples/demo/composer.json":                 "{}",
+		"docs/README.md":                              "# Docs\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:gosec
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil { //nolint:gosec
+			t.Fatal(err)
+		}
+	}
+
+	projects, err := DiscoverProjects(root, []string{"examples/"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{".", "apps/web", "services/api"}
+
+	if !slices.Equal(projects, want) {
+		t.Errorf("DiscoverProjects() = %v, want %v", projects, want)
+	}
+
+	if _, err := DiscoverProjects(filepath.Join(root, "missing"), nil); err == nil {
+		t.Error("DiscoverProjects() of a missing root succeeded")
+	}
+}
@@ -0,0 +1,252 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Status DESCRIBES THE OVERALL OUTCOME OF A CHECK RUN OR A SINGLE SCOPE.
+type Status string
+
+const (
+	StatusSuccess  Status = "success"
+	StatusWarning  Status = "warning"
+	StatusError    Status = "error"
+	StatusCanceled Status = "canceled"
+	StatusTimedOut Status = "timed-out"
+
+	// StatusBlocked IS ONLY USED FOR A SINGLE SCOPE, A RUN WITH BLOCKED MODULES HAS StatusError.
+	StatusBlocked Status = "blocked"
+)
+
+// EXIT CODES RETURNED BY preflight check.
+const (
+	// ExitSuccess MEANS NO FINDING MATCHED THE --fail-on POLICY.
+	ExitSuccess = 0
+
+	// ExitRequirementsFailed MEANS AT LEAST ONE FINDING MATCHED THE --fail-on POLICY.
+	ExitRequirementsFailed = 1
+
+	// ExitAborted MEANS THE RUN WAS CANCELED OR A MODULE TIMED OUT, SO NOT EVERY REQUIREMENT WAS VERIFIED.
+	ExitAborted = 2
+
+	// ExitConfigError MEANS THE CHECK COULD NOT RUN, E.G. BECAUSE OF AN INVALID FLAG OR AN UNWRITABLE REPORT.
+	ExitConfigError = 3
+)
+
+// FailOn DEFINES THE LOWEST SEVERITY THAT MAKES preflight check FAIL.
+type FailOn string
+
+const (
+	FailOnError   FailOn = "error"
+	FailOnWarning FailOn = "warning"
+	FailOnNever   FailOn = "never"
+)
+
+// ParseFailOn VALIDATES A --fail-on VALUE, AN EMPTY VALUE DEFAULTS TO FailOnError.
+func ParseFailOn(value string) (FailOn, error) {
+	switch failOn := FailOn(strings.ToLower(strings.TrimSpace(value))); failOn {
+	case "":
+		return FailOnError, nil
+	case FailOnError, FailOnWarning, FailOnNever:
+		return failOn, nil
+	default:
+		return "", fmt.Errorf("unknown --fail-on value '%s', expected error, warning or never", value)
+	}
+}
+
+// CheckReport HOLDS EVERYTHING PRODUCED BY A SINGLE CHECK RUN.
+type CheckReport struct {
+	Version   string
+	Results   []CheckResult
+	StartedAt time.Time
+	EndedAt   time.Time
+	Canceled  bool
+
+	// Detections RECORDS WHICH ECOSYSTEMS WERE DETECTED IN THE PROJECT AND WHY.
+	Detections []Detection
+
+	// FailOn IS THE POLICY USED BY ExitCode, AN EMPTY VALUE BEHAVES AS FailOnError.
+	FailOn FailOn
+
+	// Selected LISTS THE NAMES OF THE MODULES THE RUN WAS LIMITED TO, BEFORE THE ECOSYSTEMS OF THE PROJECT WERE DETECTED.
+	Selected []string
+}
+
+// Totals RETURNS THE NUMBER OF ERRORS, WARNINGS AND SUCCESSES ACROSS ALL SCOPES.
+func (r CheckReport) Totals() (errors, warnings, successes int) {
+	for _, result := range r.Results {
+		errors += len(result.Errors())
+		warnings += len(result.Warnings())
+		successes += len(result.Successes())
+	}
+
+	return errors, warnings, successes
+}
+
+// Baselined RETURNS THE NUMBER OF KNOWN FINDINGS HIDDEN BY THE BASELINE.
+func (r CheckReport) Baselined() int {
+	baselined := 0
+
+	for _, result := range r.Results {
+		baselined += len(result.Baselined)
+	}
+
+	return baselined
+}
+
+// TimedOut REPORTS WHETHER ANY MODULE TIMED OUT.
+func (r CheckReport) TimedOut() bool {
+	for _, result := range r.Results {
+		if result.TimedOut {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Status RETURNS THE OVERALL STATUS OF THE RUN.
+func (r CheckReport) Status() Status {
+	errors, warnings, _ := r.Totals()
+
+	switch {
+	case r.Canceled:
+		return StatusCanceled
+	case r.TimedOut():
+		return StatusTimedOut
+	case errors > 0:
+		return StatusError
+	case warnings > 0:
+		return StatusWarning
+	default:
+		return StatusSuccess
+	}
+}
+
+// ExitCode RETURNS THE PROCESS EXIT CODE MATCHING THE REPORT STATUS AND THE --fail-on POLICY.
+// ABORTED RUNS ALWAYS FAIL, SINCE THEIR REQUIREMENTS WERE NOT FULLY VERIFIED.
+// A BLOCKED MODULE IS REPORTED WITH AN ERROR FINDING, SO IT FAILS THE RUN UNDER --fail-on=error AS WELL.
+func (r CheckReport) ExitCode() int {
+	errors, warnings, _ := r.Totals()
+
+	switch {
+	case r.Canceled || r.TimedOut():
+		return ExitAborted
+	case r.FailOn == FailOnNever:
+		return ExitSuccess
+	case errors > 0:
+		return ExitRequirementsFailed
+	case warnings > 0 && r.FailOn == FailOnWarning:
+		return ExitRequirementsFailed
+	default:
+		return ExitSuccess
+	}
+}
+
+// Status RETURNS THE STATUS OF A SINGLE SCOPE.
+func (r CheckResult) Status() Status {
+	switch {
+	case r.TimedOut:
+		return StatusTimedOut
+	case len(r.BlockedBy) > 0:
+		return StatusBlocked
+	case len(r.Errors()) > 0:
+		return StatusError
+	case len(r.Warnings()) > 0:
+		return StatusWarning
+	default:
+		return StatusSuccess
+	}
+}
+
+// REPORT FORMATS SUPPORTED BY preflight check.
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatSARIF    = "sarif"
+	FormatJUnit    = "junit"
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+
+	FormatGitHub            = "github"
+	FormatGitLabCodeQuality = "gitlab-codequality"
+
+	FormatTemplate = "template"
+)
+
+// ReportRenderer WRITES A CHECK REPORT IN A SPECIFIC FORMAT.
+type ReportRenderer func(w io.Writer, report CheckReport) error
+
+// reportRenderers MAPS EACH FORMAT NAME TO ITS RENDERER.
+var reportRenderers = map[string]ReportRenderer{
+	FormatText:     renderText,
+	FormatJSON:     renderJSON,
+	FormatSARIF:    renderSARIF,
+	FormatJUnit:    renderJUnit,
+	FormatMarkdown: renderMarkdown,
+	FormatHTML:     renderHTML,
+
+	FormatGitHub:            renderGitHub,
+	FormatGitLabCodeQuality: renderGitLabCodeQuality,
+
+	// THE TEMPLATE RENDERER IS CREATED PER RUN FROM THE --template FILE.
+	FormatTemplate: nil,
+}
+
+// isTerminalFormat REPORTS WHETHER THE FORMAT IS MEANT TO BE READ IN A TERMINAL ALONGSIDE THE PROGRESS OUTPUT.
+func isTerminalFormat(format string) bool {
+	return format == FormatText || format == FormatGitHub
+}
+
+// ReportFormats RETURNS THE NAMES OF ALL SUPPORTED REPORT FORMATS.
+func ReportFormats() []string {
+	formats := make([]string, 0, len(reportRenderers))
+
+	for name := range reportRenderers {
+		formats = append(formats, name)
+	}
+
+	sort.Strings(formats)
+
+	return formats
+}
+
+// getReportRenderer RETURNS THE RENDERER FOR THE GIVEN FORMAT.
+func getReportRenderer(format, templatePath string) (ReportRenderer, error) {
+	renderer, ok := reportRenderers[strings.ToLower(format)]
+
+	if !ok {
+		return nil, fmt.Errorf("unknown format '%s', expected one of: %s", format, strings.Join(ReportFormats(), ", "))
+	}
+
+	if strings.ToLower(format) == FormatTemplate {
+		return newTemplateRenderer(templatePath)
+	}
+
+	return renderer, nil
+}
+
+// writeOutput CALLS render WITH THE GIVEN FILE OR WITH STDOUT WHEN NO FILE IS SET.
+func writeOutput(output string, render func(w io.Writer) error) error {
+	if output == "" {
+		return render(os.Stdout)
+	}
+
+	file, err := os.Create(output) //nolint:gosec
+
+	if err != nil {
+		return fmt.Errorf("unable to create %s: %w", output, err)
+	}
+
+	if err := render(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// GitLabCodeQualityFile IS THE DEFAULT ARTIFACT NAME GitLab USES FOR CODE QUALITY REPORTS.
+const GitLabCodeQualityFile = "gl-code-quality-report.json"
+
+// DetectCIFormat RETURNS THE NATIVE ANNOTATION FORMAT OF THE CURRENT CI ENVIRONMENT, OR AN EMPTY STRING.
+func DetectCIFormat() string {
+	switch {
+	case os.Getenv("GITHUB_ACTIONS") == "true":
+		return FormatGitHub
+	case os.Getenv("GITLAB_CI") != "":
+		return FormatGitLabCodeQuality
+	default:
+		return ""
+	}
+}
+
+// renderGitHub WRITES EVERY ERROR AND WARNING AS A GitHub ACTIONS WORKFLOW COMMAND FOLLOWED BY THE TEXT REPORT.
+func renderGitHub(w io.Writer, report CheckReport) error {
+	locator := newLineLocator()
+
+	for _, result := range report.Results {
+		for _, finding := range result.Findings {
+			if finding.Severity == SeveritySuccess {
+				continue
+			}
+
+			command := "error"
+
+			if finding.Severity == SeverityWarning {
+				command = "warning"
+			}
+
+			properties := make([]string, 0, 3)
+
+			if finding.File != "" {
+				properties = append(properties, "file="+escapeGitHubProperty(locator.Path(finding)))
+
+				if line := locator.Line(finding); line > 0 {
+					properties = append(properties, fmt.Sprintf("line=%d", line))
+				}
+			}
+
+			properties = append(properties, "title="+escapeGitHubProperty(finding.Module+": "+finding.RuleID))
+
+			if _, err := fmt.Fprintf(w, "::%s %s::%s\n", command, strings.Join(properties, ","), escapeGitHubData(finding.Message)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return renderText(w, report)
+}
+
+// escapeGitHubData ESCAPES THE MESSAGE OF A WORKFLOW COMMAND.
+func escapeGitHubData(text string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(text)
+}
+
+// escapeGitHubProperty ESCAPES A PROPERTY VALUE OF A WORKFLOW COMMAND.
+func escapeGitHubProperty(text string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(text)
+}
+
+// codeQualityIssue IS A SINGLE ENTRY OF A GitLab CODE QUALITY (Code Climate) REPORT.
+type codeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeQualityLocation `json:"location"`
+}
+
+type codeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines codeQualityLines `json:"lines"`
+}
+
+type codeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+// renderGitLabCodeQuality WRITES EVERY ERROR AND WARNING AS A GitLab CODE QUALITY ISSUE.
+func renderGitLabCodeQuality(w io.Writer, report CheckReport) error {
+	locator := newLineLocator()
+	issues := make([]codeQualityIssue, 0)
+
+	for _, result := range report.Results {
+		for _, finding := range result.Findings {
+			if finding.Severity == SeveritySuccess {
+				continue
+			}
+
+			severity := "major"
+
+			if finding.Severity == SeverityWarning {
+				severity = "minor"
+			}
+
+			// GitLab REQUIRES A LOCATION, FINDINGS WITHOUT A MANIFEST ARE ATTACHED TO THE PROJECT ROOT.
+			path, line := locator.Path(finding), locator.Line(finding)
+
+			if path == "" {
+				path = "."
+			}
+
+			if line == 0 {
+				line = 1
+			}
+
+			issues = append(issues, codeQualityIssue{
+				Description: finding.Message,
+				CheckName:   finding.RuleID,
+				Fingerprint: findingFingerprint(finding),
+				Severity:    severity,
+				Location: codeQualityLocation{
+					Path:  path,
+					Lines: codeQualityLines{Begin: line},
+				},
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(issues)
+}
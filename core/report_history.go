@@ -0,0 +1,172 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"io"
+	"slices"
+	"strings"
+)
+
+// HistoryOptions CONFIGURES HOW WriteHistory RENDERS THE TRENDS OF A PROJECT.
+type HistoryOptions struct {
+	// Format IS text OR json.
+	Format string
+
+	// Output FILE, STDOUT IS USED WHEN EMPTY.
+	Output string
+
+	// Limit IS THE NUMBER OF MOST RECENT RUNS AND EVENTS SHOWN IN TEXT, ZERO SHOWS ALL.
+	Limit int
+}
+
+// WriteHistory RENDERS THE TRENDS OF A PROJECT HISTORY.
+func WriteHistory(trends HistoryTrends, options HistoryOptions) error {
+	switch strings.ToLower(options.Format) {
+	case "", FormatText:
+		return writeOutput(options.Output, func(w io.Writer) error { return renderHistoryText(w, trends, options.Limit) })
+	case FormatJSON:
+		return writeOutput(options.Output, func(w io.Writer) error {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+
+			return encoder.Encode(trends)
+		})
+	default:
+		return fmt.Errorf("unsupported history format: %s (supported: text, json)", options.Format)
+	}
+}
+
+// renderHistoryText WRITES THE RECENT RUNS, THE REQUIREMENT CHANGES AND THE DURATION TREND OF EVERY MODULE.
+func renderHistoryText(w io.Writer, trends HistoryTrends, limit int) error {
+	ow := utils.NewOutputWriterTo(w)
+
+	if len(trends.Runs) == 0 {
+		if !ow.Println(utils.Yellow + "No runs recorded yet, record them with preflight check --history." + utils.Reset) {
+			return fmt.Errorf("unable to write history")
+		}
+
+		return nil
+	}
+
+	ow.Println(utils.Bold + fmt.Sprintf("\nRuns (%d recorded):", len(trends.Runs)) + utils.Reset)
+
+	for _, run := range lastN(trends.Runs, limit) {
+		icon, color, _ := statusSummary(run.Status)
+		line := fmt.Sprintf("%s  %s %s  %-9s %-22s %6dms", color, icon, run.StartedAt.Local().Format(endedAtLayout), run.Status, historyCounts(run), run.DurationMs)
+
+		if len(run.ChangedManifests) > 0 {
+			line += utils.Dim + "  " + strings.Join(run.ChangedManifests, ", ") + " changed"
+		}
+
+		ow.Println(line + utils.Reset)
+	}
+
+	ow.Println(utils.Bold + "\nRequirement changes:" + utils.Reset)
+
+	if len(trends.Events) == 0 {
+		ow.Println(utils.Dim + "  No requirement changed." + utils.Reset)
+	}
+
+	for _, event := range lastN(trends.Events, limit) {
+		requirement := event.Module + " " + event.RuleID
+
+		if event.Subject != "" {
+			requirement += " (" + event.Subject + ")"
+		}
+
+		at := event.At.Local().Format(endedAtLayout)
+
+		switch event.Event {
+		case EventIntroduced:
+			ow.Printf("%s  + %s  %s introduced%s%s\n", utils.Cyan, at, requirement, changedBy(event.Manifests), utils.Reset)
+		case EventRemoved:
+			ow.Printf("%s  - %s  %s removed%s%s\n", utils.Dim, at, requirement, changedBy(event.Manifests), utils.Reset)
+		case EventFailing:
+			icon, color := utils.CrossMark, utils.Red
+
+			if event.Severity == SeverityWarning {
+				icon, color = utils.WarningSign, utils.Yellow
+			}
+
+			ow.Printf("%s  %s %s  %s started failing: %s%s\n", color, icon, at, requirement, event.Message, utils.Reset)
+		case EventFixed:
+			ow.Printf("%s  %s %s  %s stopped failing%s\n", utils.Green, utils.CheckMark, at, requirement, utils.Reset)
+		}
+	}
+
+	ow.Println(utils.Bold + "\nModule durations:" + utils.Reset)
+
+	width := 0
+
+	for _, durations := range trends.Durations {
+		width = max(width, len(durations.Module))
+	}
+
+	for _, durations := range trends.Durations {
+		values := durations.DurationsMs
+		first, last := values[0], values[len(values)-1]
+
+		ow.Printf("  %-*s  %8s  first %6dms  last %6dms  min %6dms  max %6dms  %s\n",
+			width, durations.Module, plural(len(values), "run"), first, last, slices.Min(values), slices.Max(values), sparkline(lastN(values, 20)))
+	}
+
+	if !ow.Println("") {
+		return fmt.Errorf("unable to write history")
+	}
+
+	return nil
+}
+
+// historyCounts DESCRIBES THE ERRORS AND WARNINGS OF A RUN.
+func historyCounts(run HistoryRun) string {
+	if run.Errors == 0 && run.Warnings == 0 {
+		return "no findings"
+	}
+
+	return plural(run.Errors, "error") + ", " + plural(run.Warnings, "warning")
+}
+
+// changedBy NAMES THE MANIFESTS THAT CHANGED ALONG WITH A REQUIREMENT.
+func changedBy(manifests []string) string {
+	if len(manifests) == 0 {
+		return ""
+	}
+
+	return " by a change of " + strings.Join(manifests, ", ")
+}
+
+// sparklineBars ARE THE BARS OF A SPARKLINE, FROM LOWEST TO HIGHEST.
+var sparklineBars = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline DRAWS THE VALUES AS A SPARKLINE, SCALED BETWEEN THEIR MINIMUM AND MAXIMUM.
+func sparkline(values []int64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	low, high := slices.Min(values), slices.Max(values)
+	bars := make([]rune, len(values))
+
+	for i, value := range values {
+		index := 0
+
+		if high > low {
+			index = int((value - low) * int64(len(sparklineBars)-1) / (high - low))
+		}
+
+		bars[i] = sparklineBars[index]
+	}
+
+	return string(bars)
+}
+
+// lastN RETURNS THE LAST n ELEMENTS, OR ALL ELEMENTS WHEN n IS ZERO.
+func lastN[T any](values []T, n int) []T {
+	if n <= 0 || n >= len(values) {
+		return values
+	}
+
+	return values[len(values)-n:]
+}
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"html/template"
+	"io"
+	"time"
+)
+
+// htmlStyle IS INLINED INTO EVERY HTML REPORT SO THE FILE HAS NO EXTERNAL ASSETS.
+const htmlStyle = `
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem auto; max-width: 1100px; color: #1f2328; }
+h1 { font-size: 1.5rem; }
+.summary { border: 1px solid #d0d7de; border-radius: 6px; padding: 0.75rem 1rem; margin-bottom: 1.5rem; }
+.summary .ended { color: #656d76; font-size: 0.9rem; }
+details { border: 1px solid #d0d7de; border-radius: 6px; margin-bottom: 0.75rem; }
+summary { cursor: pointer; padding: 0.5rem 1rem; font-weight: 600; }
+table { border-collapse: collapse; width: 100%; font-size: 0.9rem; }
+th, td { border-top: 1px solid #d0d7de; padding: 0.4rem 1rem; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+code { font-size: 0.85rem; }
+.error { color: #cf222e; }
+.warning { color: #9a6700; }
+.success { color: #1a7f37; }
+.canceled { color: #cf222e; }
+.muted { color: #656d76; }
+`
+
+var htmlFuncs = template.FuncMap{
+	"icon":     severityIcon,
+	"duration": formatDuration,
+	"manager":  displayManagerName,
+	"dash": func(text string) string {
+		if text == "" {
+			return "–"
+		}
+
+		return text
+	},
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(htmlFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>PreFlight Checker</title>
+<style>{{ .Style }}</style>
+</head>
+<body>
+<h1>🚀 PreFlight Checker</h1>
+<div class="summary">
+<div class="{{ .Status }}"><strong>{{ .StatusIcon }} Status: {{ .StatusText }}</strong></div>
+<div class="ended">Ended: {{ .EndedAt }} · {{ .Errors }} errors · {{ .Warnings }} warnings · {{ .Successes }} successes{{ if .Baselined }} · {{ .Baselined }} known findings hidden by the baseline{{ end }}</div>
+</div>
+{{- range .Results }}
+<details{{ if eq .Status "error" }} open{{ end }}>
+<summary><span class="{{ .Status }}">{{ .Icon }}</span> {{ .Scope }} <span class="muted">({{ duration .Duration }})</span></summary>
+<table>
+<tr><th></th><th>Rule</th><th>Subject</th><th>Required</th><th>Installed</th><th>Message</th></tr>
+{{- range .Findings }}
+<tr class="{{ .Severity }}"><td>{{ icon .Severity }}</td><td><code>{{ .RuleID }}</code></td><td>{{ dash .Subject }}</td><td>{{ dash .Required }}</td><td>{{ dash .Installed }}</td><td>{{ .Message }}</td></tr>
+{{- end }}
+</table>
+</details>
+{{- end }}
+</body>
+</html>
+`))
+
+var htmlDependenciesTemplate = template.Must(template.New("dependencies").Funcs(htmlFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>PreFlight Dependencies</title>
+<style>{{ .Style }}</style>
+</head>
+<body>
+<h1>🚀 Scanning project for dependencies</h1>
+{{- if not .Managers }}
+<div class="summary error">No package managers detected in this project!</div>
+{{- end }}
+{{- range .Managers }}
+<details open>
+<summary>{{ manager .Name }} Dependencies <span class="muted">({{ len .Dependencies }})</span></summary>
+<table>
+<tr><th>Dependency</th></tr>
+{{- range .Dependencies }}
+<tr><td>{{ . }}</td></tr>
+{{- end }}
+</table>
+</details>
+{{- end }}
+</body>
+</html>
+`))
+
+// htmlResult IS THE TEMPLATE DATA OF A SINGLE SCOPE.
+type htmlResult struct {
+	Scope    string
+	Duration time.Duration
+	Icon     string
+	Status   Status
+	Findings []Finding
+}
+
+// renderHTML WRITES THE REPORT AS A SINGLE SELF-CONTAINED HTML PAGE.
+func renderHTML(w io.Writer, report CheckReport) error {
+	errors, warnings, successes := report.Totals()
+	statusIcon, _, statusText := statusSummary(report.Status())
+
+	results := make([]htmlResult, 0, len(report.Results))
+
+	for _, result := range report.Results {
+		icon, _, _ := statusSummary(result.Status())
+
+		results = append(results, htmlResult{
+			Scope:    result.Scope,
+			Duration: result.Duration,
+			Icon:     icon,
+			Status:   result.Status(),
+			Findings: sortedFindings(result),
+		})
+	}
+
+	return htmlReportTemplate.Execute(w, map[string]any{
+		"Style":      template.CSS(htmlStyle), //nolint:gosec
+		"Status":     report.Status(),
+		"StatusIcon": statusIcon,
+		"StatusText": statusText,
+		"EndedAt":    report.EndedAt.Format(endedAtLayout),
+		"Errors":     errors,
+		"Warnings":   warnings,
+		"Successes":  successes,
+		"Baselined":  report.Baselined(),
+		"Results":    results,
+	})
+}
+
+// renderDependenciesHTML WRITES THE DEPENDENCY LIST AS A SINGLE SELF-CONTAINED HTML PAGE.
+func renderDependenciesHTML(w io.Writer, result DependencyResult) error {
+	type manager struct {
+		Name         string
+		Dependencies []string
+	}
+
+	managers := make([]manager, 0, len(result.Dependencies))
+
+	for _, name := range result.SortedManagers() {
+		managers = append(managers, manager{Name: name, Dependencies: result.Dependencies[name]})
+	}
+
+	return htmlDependenciesTemplate.Execute(w, map[string]any{
+		"Style":    template.CSS(htmlStyle), //nolint:gosec
+		"Managers": managers,
+	})
+}
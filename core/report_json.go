@@ -0,0 +1,111 @@
+package core
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// JSONSchemaVersion IS INCREMENTED WHENEVER THE JSON REPORT CHANGES IN AN INCOMPATIBLE WAY.
+const JSONSchemaVersion = 1
+
+// JSONReport IS THE DOCUMENT WRITTEN BY preflight check --format json.
+type JSONReport struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	Tool          JSONTool          `json:"tool"`
+	StartedAt     time.Time         `json:"startedAt"`
+	EndedAt       time.Time         `json:"endedAt"`
+	DurationMs    int64             `json:"durationMs"`
+	Status        Status            `json:"status"`
+	ExitCode      int               `json:"exitCode"`
+	Summary       JSONSummary       `json:"summary"`
+	Detections    []Detection       `json:"detections"`
+	Results       []JSONCheckResult `json:"results"`
+}
+
+// JSONTool IDENTIFIES THE PreFlight BUILD THAT PRODUCED THE REPORT.
+type JSONTool struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// JSONSummary HOLDS THE TOTAL NUMBER OF FINDINGS PER SEVERITY.
+type JSONSummary struct {
+	Errors    int `json:"errors"`
+	Warnings  int `json:"warnings"`
+	Successes int `json:"successes"`
+	Baselined int `json:"baselined"`
+}
+
+// JSONCheckResult HOLDS THE FINDINGS OF A SINGLE SCOPE.
+type JSONCheckResult struct {
+	Scope      string    `json:"scope"`
+	Status     Status    `json:"status"`
+	DurationMs int64     `json:"durationMs"`
+	Errors     []Finding `json:"errors"`
+	Warnings   []Finding `json:"warnings"`
+	Successes  []Finding `json:"successes"`
+
+	// Baselined HOLDS THE KNOWN FINDINGS HIDDEN BY THE BASELINE, THEY DO NOT AFFECT THE STATUS.
+	Baselined []Finding `json:"baselined"`
+
+	// TimedOut IS SET WHEN THE MODULE WAS STOPPED BY A TIMEOUT, Unverified THEN LISTS THE REQUIREMENTS IT DID NOT CHECK.
+	TimedOut   bool     `json:"timedOut"`
+	Unverified []string `json:"unverified"`
+
+	BlockedBy []string `json:"blockedBy,omitempty"`
+	Cached    bool     `json:"cached,omitempty"`
+}
+
+// NewJSONReport CONVERTS A CHECK REPORT INTO ITS JSON DOCUMENT.
+func NewJSONReport(report CheckReport) JSONReport {
+	errors, warnings, successes := report.Totals()
+
+	document := JSONReport{
+		SchemaVersion: JSONSchemaVersion,
+		Tool:          JSONTool{Name: "PreFlight", Version: report.Version},
+		StartedAt:     report.StartedAt,
+		EndedAt:       report.EndedAt,
+		DurationMs:    report.EndedAt.Sub(report.StartedAt).Milliseconds(),
+		Status:        report.Status(),
+		ExitCode:      report.ExitCode(),
+		Summary:       JSONSummary{Errors: errors, Warnings: warnings, Successes: successes, Baselined: report.Baselined()},
+		Detections:    append([]Detection{}, report.Detections...),
+		Results:       make([]JSONCheckResult, 0, len(report.Results)),
+	}
+
+	for _, result := range report.Results {
+		document.Results = append(document.Results, JSONCheckResult{
+			Scope:      result.Scope,
+			Status:     result.Status(),
+			DurationMs: result.Duration.Milliseconds(),
+			Errors:     nonNilFindings(result.Errors()),
+			Warnings:   nonNilFindings(result.Warnings()),
+			Successes:  nonNilFindings(result.Successes()),
+			Baselined:  nonNilFindings(result.Baselined),
+			TimedOut:   result.TimedOut,
+			Unverified: append([]string{}, result.Unverified...),
+			BlockedBy:  result.BlockedBy,
+			Cached:     result.Cached,
+		})
+	}
+
+	return document
+}
+
+// renderJSON WRITES THE REPORT AS AN INDENTED JSON DOCUMENT.
+func renderJSON(w io.Writer, report CheckReport) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(NewJSONReport(report))
+}
+
+// nonNilFindings ENSURES EMPTY LISTS ARE ENCODED AS [] INSTEAD OF null.
+func nonNilFindings(findings []Finding) []Finding {
+	if findings == nil {
+		return []Finding{}
+	}
+
+	return findings
+}
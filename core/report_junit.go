@@ -0,0 +1,139 @@
+package core
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// junitTestSuites IS THE ROOT ELEMENT OF A JUnit XML REPORT.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// renderJUnit WRITES EACH SCOPE AS A TEST SUITE AND EACH REQUIREMENT AS A TEST CASE.
+// ERRORS BECOME FAILURES, WARNINGS ARE RECORDED AS system-out OF A PASSING TEST CASE.
+func renderJUnit(w io.Writer, report CheckReport) error {
+	suites := junitTestSuites{
+		Name:   "PreFlight",
+		Time:   junitSeconds(report.EndedAt.Sub(report.StartedAt).Seconds()),
+		Suites: make([]junitTestSuite, 0, len(report.Results)),
+	}
+
+	for _, result := range report.Results {
+		suite := junitTestSuite{
+			Name:      result.Scope,
+			Time:      junitSeconds(result.Duration.Seconds()),
+			Timestamp: report.StartedAt.Format("2006-01-02T15:04:05"),
+			TestCases: make([]junitTestCase, 0, len(result.Findings)),
+		}
+
+		for _, finding := range result.Findings {
+			testCase := junitTestCase{
+				Name:      junitTestName(finding),
+				ClassName: "preflight." + strings.ToLower(result.Scope),
+			}
+
+			switch finding.Severity {
+			case SeverityError:
+				testCase.Failure = &junitFailure{
+					Message: finding.Message,
+					Type:    finding.RuleID,
+					Body:    junitFailureBody(finding),
+				}
+				suite.Failures++
+			case SeverityWarning:
+				testCase.SystemOut = "WARNING: " + finding.Message
+			}
+
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+
+		suite.Tests = len(suite.TestCases)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+// junitTestName NAMES A TEST CASE AFTER THE RULE AND SUBJECT OF THE FINDING.
+func junitTestName(finding Finding) string {
+	if finding.Subject == "" {
+		return finding.RuleID
+	}
+
+	return finding.RuleID + ": " + finding.Subject
+}
+
+// junitFailureBody DESCRIBES THE VERSIONS AND REMEDIATION OF A FAILED REQUIREMENT.
+func junitFailureBody(finding Finding) string {
+	var lines []string
+
+	if finding.Required != "" {
+		lines = append(lines, "Required: "+finding.Required)
+	}
+
+	if finding.Installed != "" {
+		lines = append(lines, "Installed: "+finding.Installed)
+	}
+
+	if finding.File != "" {
+		lines = append(lines, "File: "+finding.File)
+	}
+
+	if finding.Remediation != "" {
+		lines = append(lines, "Remediation: "+finding.Remediation)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// junitSeconds FORMATS A DURATION IN SECONDS AS EXPECTED BY THE time ATTRIBUTE.
+func junitSeconds(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"io"
+	"strings"
+	"time"
+)
+
+// renderMarkdown WRITES THE REPORT AS GitHub FLAVORED MARKDOWN WITH A COLLAPSIBLE SECTION PER SCOPE.
+func renderMarkdown(w io.Writer, report CheckReport) error {
+	var sb strings.Builder
+
+	errors, warnings, successes := report.Totals()
+	statusIcon, _, statusText := statusSummary(report.Status())
+
+	sb.WriteString("## 🚀 PreFlight Checker\n\n")
+	fmt.Fprintf(&sb, "**%s Status: %s**\n\n", statusIcon, statusText)
+	fmt.Fprintf(&sb, "%s Ended: %s · %d errors · %d warnings · %d successes", strings.TrimSpace(utils.Clock), report.EndedAt.Format(endedAtLayout), errors, warnings, successes)
+
+	if baselined := report.Baselined(); baselined > 0 {
+		fmt.Fprintf(&sb, " · %d known findings hidden by the baseline", baselined)
+	}
+
+	sb.WriteString("\n")
+
+	for _, result := range report.Results {
+		icon, _, _ := statusSummary(result.Status())
+		open := ""
+
+		if result.Status() == StatusError {
+			open = " open"
+		}
+
+		fmt.Fprintf(&sb, "\n<details%s>\n<summary><strong>%s %s</strong> (%s)</summary>\n\n", open, icon, result.Scope, formatDuration(result.Duration))
+		sb.WriteString("| | Rule | Subject | Required | Installed | Message |\n")
+		sb.WriteString("|---|---|---|---|---|---|\n")
+
+		for _, finding := range sortedFindings(result) {
+			fmt.Fprintf(&sb, "| %s | `%s` | %s | %s | %s | %s |\n",
+				severityIcon(finding.Severity),
+				finding.RuleID,
+				markdownCell(finding.Subject),
+				markdownCell(finding.Required),
+				markdownCell(finding.Installed),
+				markdownCell(finding.Message),
+			)
+		}
+
+		sb.WriteString("\n</details>\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+// renderDependenciesMarkdown WRITES THE DEPENDENCY LIST AS MARKDOWN WITH A COLLAPSIBLE SECTION PER PACKAGE MANAGER.
+func renderDependenciesMarkdown(w io.Writer, result DependencyResult) error {
+	var sb strings.Builder
+
+	sb.WriteString("## 🚀 Scanning project for dependencies\n")
+
+	if len(result.Dependencies) == 0 {
+		sb.WriteString("\nNo package managers detected in this project!\n")
+	}
+
+	for _, name := range result.SortedManagers() {
+		deps := result.Dependencies[name]
+
+		fmt.Fprintf(&sb, "\n<details open>\n<summary><strong>%s Dependencies</strong> (%d)</summary>\n\n", displayManagerName(name), len(deps))
+		sb.WriteString("| Dependency |\n")
+		sb.WriteString("|---|\n")
+
+		for _, dep := range deps {
+			fmt.Fprintf(&sb, "| %s |\n", markdownCell(dep))
+		}
+
+		sb.WriteString("\n</details>\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+// sortedFindings RETURNS THE FINDINGS OF A SCOPE ORDERED BY ERRORS, WARNINGS AND SUCCESSES.
+func sortedFindings(result CheckResult) []Finding {
+	findings := make([]Finding, 0, len(result.Findings))
+	findings = append(findings, result.Errors()...)
+	findings = append(findings, result.Warnings()...)
+	findings = append(findings, result.Successes()...)
+
+	return findings
+}
+
+// severityIcon RETURNS THE SYMBOL USED FOR A SEVERITY IN PLAIN TEXT REPORTS.
+func severityIcon(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return utils.CrossMark
+	case SeverityWarning:
+		return utils.WarningSign
+	default:
+		return utils.CheckMark
+	}
+}
+
+// markdownCell ESCAPES TEXT FOR USE INSIDE A MARKDOWN TABLE CELL.
+func markdownCell(text string) string {
+	if text == "" {
+		return "–"
+	}
+
+	replacer := strings.NewReplacer("|", "\\|", "\n", " ", "<", "&lt;", ">", "&gt;")
+
+	return replacer.Replace(text)
+}
+
+// formatDuration FORMATS A DURATION IN MILLISECONDS.
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"io"
+	"time"
+)
+
+// JSONProjectsReport IS THE DOCUMENT WRITTEN BY preflight check --recursive --format json.
+type JSONProjectsReport struct {
+	SchemaVersion int                 `json:"schemaVersion"`
+	Tool          JSONTool            `json:"tool"`
+	StartedAt     time.Time           `json:"startedAt"`
+	EndedAt       time.Time           `json:"endedAt"`
+	DurationMs    int64               `json:"durationMs"`
+	Status        Status              `json:"status"`
+	ExitCode      int                 `json:"exitCode"`
+	Summary       JSONSummary         `json:"summary"`
+	Projects      []JSONProjectReport `json:"projects"`
+}
+
+// JSONProjectReport HOLDS THE STATUS AND RESULTS OF A SINGLE PROJECT.
+type JSONProjectReport struct {
+	Dir        string            `json:"dir"`
+	Status     Status            `json:"status"`
+	ExitCode   int               `json:"exitCode"`
+	Summary    JSONSummary       `json:"summary"`
+	Detections []Detection       `json:"detections"`
+	Results    []JSONCheckResult `json:"results"`
+}
+
+// NewJSONProjectsReport CONVERTS THE PROJECT REPORTS OF A RECURSIVE CHECK INTO THEIR JSON DOCUMENT.
+func NewJSONProjectsReport(projects []ProjectReport) JSONProjectsReport {
+	merged := NewJSONReport(MergeProjectReports(projects))
+
+	document := JSONProjectsReport{
+		SchemaVersion: merged.SchemaVersion,
+		Tool:          merged.Tool,
+		StartedAt:     merged.StartedAt,
+		EndedAt:       merged.EndedAt,
+		DurationMs:    merged.DurationMs,
+		Status:        merged.Status,
+		ExitCode:      merged.ExitCode,
+		Summary:       merged.Summary,
+		Projects:      make([]JSONProjectReport, 0, len(projects)),
+	}
+
+	for _, project := range projects {
+		report := NewJSONReport(project.CheckReport)
+
+		document.Projects = append(document.Projects, JSONProjectReport{
+			Dir:        project.Dir,
+			Status:     report.Status,
+			ExitCode:   report.ExitCode,
+			Summary:    report.Summary,
+			Detections: report.Detections,
+			Results:    report.Results,
+		})
+	}
+
+	return document
+}
+
+// renderProjectsJSON WRITES THE PROJECT REPORTS AS AN INDENTED JSON DOCUMENT.
+func renderProjectsJSON(w io.Writer, projects []ProjectReport) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(NewJSONProjectsReport(projects))
+}
+
+// renderProjectsText WRITES THE HUMAN-READABLE REPORT GROUPED BY PROJECT, FOLLOWED BY THE STATUS OF EVERY PROJECT.
+func renderProjectsText(w io.Writer, projects []ProjectReport, merged CheckReport) error {
+	ow := utils.NewOutputWriterTo(w)
+
+	for _, project := range projects {
+		icon, color, _ := statusSummary(project.Status())
+
+		if !ow.Println(utils.Bold+color+"\n━━ Project: "+project.Dir+" "+icon+" "+string(project.Status())+utils.Reset) ||
+			!printDetections(ow, project.Detections) || !printResults(ow, project.Results) {
+			return fmt.Errorf("unable to write report")
+		}
+	}
+
+	if !ow.Println(utils.Bold + "\nProjects:" + utils.Reset) {
+		return fmt.Errorf("unable to write report")
+	}
+
+	width := 0
+
+	for _, project := range projects {
+		width = max(width, len(project.Dir))
+	}
+
+	for _, project := range projects {
+		icon, color, _ := statusSummary(project.Status())
+		ow.Printf("%s    %s %-*s  %s%s\n", color, icon, width, project.Dir, project.Status(), utils.Reset)
+	}
+
+	if !finalMessage(ow, merged) {
+		return fmt.Errorf("unable to write report")
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+)
+
+// sarifSourceRoot IS THE uriBaseId OF THE REPOSITORY ROOT.
+const sarifSourceRoot = "%SRCROOT%"
+
+// sarifLog IS THE ROOT OBJECT OF A SARIF 2.1.0 DOCUMENT.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	Help                 sarifMessage       `json:"help"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful bool `json:"executionSuccessful"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+// sarifArtifactLocation IS RELATIVE TO sarifSourceRoot, WHICH CODE SCANNING RESOLVES TO THE CHECKED OUT REPOSITORY.
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// renderSARIF WRITES ALL ERRORS AND WARNINGS AS A SARIF 2.1.0 LOG.
+func renderSARIF(w io.Writer, report CheckReport) error {
+	rules := Rules()
+	ruleIndex := make(map[string]int, len(rules))
+
+	for i, rule := range rules {
+		ruleIndex[rule.ID] = i
+	}
+
+	locator := newLineLocator()
+	results := make([]sarifResult, 0)
+
+	for _, result := range report.Results {
+		for _, finding := range result.Findings {
+			if finding.Severity == SeveritySuccess {
+				continue
+			}
+
+			// RULES UNKNOWN TO THE CATALOG ARE APPENDED SO EVERY RESULT HAS A DESCRIPTOR.
+			index, ok := ruleIndex[finding.RuleID]
+
+			if !ok {
+				index = len(rules)
+				ruleIndex[finding.RuleID] = index
+				rules = append(rules, LookupRule(finding.RuleID))
+			}
+
+			sarif := sarifResult{
+				RuleID:    finding.RuleID,
+				RuleIndex: index,
+				Level:     sarifLevel(finding.Severity),
+				Message:   sarifMessage{Text: finding.Message},
+				PartialFingerprints: map[string]string{
+					"preflightFinding/v1": findingFingerprint(finding),
+				},
+			}
+
+			if finding.File != "" {
+				location := sarifLocation{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: locator.Path(finding), URIBaseID: sarifSourceRoot},
+					},
+				}
+
+				if line := locator.Line(finding); line > 0 {
+					location.PhysicalLocation.Region = &sarifRegion{StartLine: line}
+				}
+
+				sarif.Locations = []sarifLocation{location}
+			}
+
+			results = append(results, sarif)
+		}
+	}
+
+	descriptors := make([]sarifRule, 0, len(rules))
+
+	for _, rule := range rules {
+		descriptors = append(descriptors, sarifRule{
+			ID:                   rule.ID,
+			Name:                 rule.Name,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			Help:                 sarifMessage{Text: rule.Help},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Level)},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "PreFlight",
+						Version:        report.Version,
+						InformationURI: "https://github.com/MineHubs-Studios/PreFlight",
+						Rules:          descriptors,
+					},
+				},
+				Invocations: []sarifInvocation{{ExecutionSuccessful: !report.Canceled}},
+				Results:     results,
+			},
+		},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(log)
+}
+
+// sarifLevel MAPS A FINDING SEVERITY TO A SARIF RESULT LEVEL.
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// findingFingerprint IDENTIFIES A FINDING BY ITS MODULE, RULE AND SUBJECT SO IT IS STABLE ACROSS MESSAGE CHANGES.
+func findingFingerprint(finding Finding) string {
+	sum := sha256.Sum256([]byte(finding.Module + "\x00" + finding.RuleID + "\x00" + finding.Subject))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,247 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateReport IS THE DATA PASSED TO USER-SUPPLIED TEMPLATES BY preflight check.
+type TemplateReport struct {
+	Version    string
+	Status     Status
+	StatusText string
+	ExitCode   int
+	StartedAt  time.Time
+	EndedAt    time.Time
+	Duration   time.Duration
+	Summary    JSONSummary
+	Detections []Detection
+	Results    []TemplateResult
+	Findings   []Finding
+}
+
+// TemplateResult IS THE TEMPLATE DATA OF A SINGLE SCOPE.
+type TemplateResult struct {
+	Scope     string
+	Status    Status
+	Duration  time.Duration
+	Findings  []Finding
+	Errors    []Finding
+	Warnings  []Finding
+	Successes []Finding
+}
+
+// TemplateDependencies IS THE DATA PASSED TO USER-SUPPLIED TEMPLATES BY preflight list.
+type TemplateDependencies struct {
+	Managers []TemplateManager
+}
+
+// TemplateManager IS THE TEMPLATE DATA OF A SINGLE PACKAGE MANAGER.
+type TemplateManager struct {
+	Name         string
+	DisplayName  string
+	Dependencies []string
+}
+
+// FindingGroup IS A SET OF FINDINGS SHARING THE SAME KEY, AS RETURNED BY THE groupBy TEMPLATE FUNCTION.
+type FindingGroup struct {
+	Key      string
+	Findings []Finding
+}
+
+// templateColors MAPS COLOR NAMES USABLE IN TEMPLATES TO ANSI CODES.
+var templateColors = map[string]string{
+	"reset":  utils.Reset,
+	"bold":   utils.Bold,
+	"dim":    utils.Dim,
+	"red":    utils.Red,
+	"green":  utils.Green,
+	"yellow": utils.Yellow,
+	"blue":   utils.Blue,
+	"cyan":   utils.Cyan,
+}
+
+// templateFuncs ARE THE HELPER FUNCTIONS AVAILABLE TO USER-SUPPLIED TEMPLATES.
+var templateFuncs = template.FuncMap{
+	"color": func(name, text string) string {
+		code, ok := templateColors[strings.ToLower(name)]
+
+		if !ok {
+			return text
+		}
+
+		return code + text + utils.Reset
+	},
+	"severityColor": func(severity Severity) string {
+		switch severity {
+		case SeverityError:
+			return "red"
+		case SeverityWarning:
+			return "yellow"
+		default:
+			return "green"
+		}
+	},
+	"padRight": func(width int, text string) string {
+		return fmt.Sprintf("%-*s", width, text)
+	},
+	"padLeft": func(width int, text string) string {
+		return fmt.Sprintf("%*s", width, text)
+	},
+	"groupBy":  groupFindings,
+	"icon":     severityIcon,
+	"duration": formatDuration,
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"join":     strings.Join,
+	"repeat":   strings.Repeat,
+	"json": func(value any) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+}
+
+// loadTemplate PARSES THE TEMPLATE FILE AT THE GIVEN PATH.
+func loadTemplate(path string) (*template.Template, error) {
+	if path == "" {
+		return nil, fmt.Errorf("the template format requires --template")
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read template: %w", err)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Funcs(templateFuncs).Parse(string(data))
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// newTemplateRenderer CREATES A ReportRenderer FROM A USER-SUPPLIED TEMPLATE FILE.
+func newTemplateRenderer(path string) (ReportRenderer, error) {
+	tmpl, err := loadTemplate(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w io.Writer, report CheckReport) error {
+		return tmpl.Execute(w, NewTemplateReport(report))
+	}, nil
+}
+
+// newDependencyTemplateRenderer CREATES A DependencyRenderer FROM A USER-SUPPLIED TEMPLATE FILE.
+func newDependencyTemplateRenderer(path string) (DependencyRenderer, error) {
+	tmpl, err := loadTemplate(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w io.Writer, result DependencyResult) error {
+		data := TemplateDependencies{Managers: make([]TemplateManager, 0, len(result.Dependencies))}
+
+		for _, name := range result.SortedManagers() {
+			data.Managers = append(data.Managers, TemplateManager{
+				Name:         name,
+				DisplayName:  displayManagerName(name),
+				Dependencies: result.Dependencies[name],
+			})
+		}
+
+		return tmpl.Execute(w, data)
+	}, nil
+}
+
+// NewTemplateReport CONVERTS A CHECK REPORT INTO THE DATA MODEL EXPOSED TO TEMPLATES.
+func NewTemplateReport(report CheckReport) TemplateReport {
+	errors, warnings, successes := report.Totals()
+	_, _, statusText := statusSummary(report.Status())
+
+	data := TemplateReport{
+		Version:    report.Version,
+		Status:     report.Status(),
+		StatusText: statusText,
+		ExitCode:   report.ExitCode(),
+		StartedAt:  report.StartedAt,
+		EndedAt:    report.EndedAt,
+		Duration:   report.EndedAt.Sub(report.StartedAt),
+		Summary:    JSONSummary{Errors: errors, Warnings: warnings, Successes: successes, Baselined: report.Baselined()},
+		Detections: report.Detections,
+		Results:    make([]TemplateResult, 0, len(report.Results)),
+	}
+
+	for _, result := range report.Results {
+		data.Findings = append(data.Findings, result.Findings...)
+		data.Results = append(data.Results, TemplateResult{
+			Scope:     result.Scope,
+			Status:    result.Status(),
+			Duration:  result.Duration,
+			Findings:  result.Findings,
+			Errors:    result.Errors(),
+			Warnings:  result.Warnings(),
+			Successes: result.Successes(),
+		})
+	}
+
+	return data
+}
+
+// groupFindings GROUPS FINDINGS BY severity, module, rule OR file, KEEPING THE ORDER IN WHICH KEYS FIRST APPEAR.
+func groupFindings(key string, findings []Finding) ([]FindingGroup, error) {
+	var keyOf func(Finding) string
+
+	switch strings.ToLower(key) {
+	case "severity":
+		keyOf = func(f Finding) string { return string(f.Severity) }
+	case "module":
+		keyOf = func(f Finding) string { return f.Module }
+	case "rule":
+		keyOf = func(f Finding) string { return f.RuleID }
+	case "file":
+		keyOf = func(f Finding) string { return f.File }
+	default:
+		return nil, fmt.Errorf("unknown group key '%s', expected severity, module, rule or file", key)
+	}
+
+	var groups []FindingGroup
+	index := make(map[string]int)
+
+	for _, finding := range findings {
+		k := keyOf(finding)
+
+		i, ok := index[k]
+
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, FindingGroup{Key: k})
+		}
+
+		groups[i].Findings = append(groups[i].Findings, finding)
+	}
+
+	// SEVERITIES ARE ALWAYS LISTED FROM MOST TO LEAST SEVERE.
+	if strings.ToLower(key) == "severity" {
+		order := map[string]int{string(SeverityError): 0, string(SeverityWarning): 1, string(SeveritySuccess): 2}
+
+		sort.SliceStable(groups, func(i, j int) bool {
+			return order[groups[i].Key] < order[groups[j].Key]
+		})
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,443 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFailOn(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    FailOn
+		wantErr bool
+	}{
+		{value: "", want: FailOnError},
+		{value: "error", want: FailOnError},
+		{value: " Warning ", want: FailOnWarning},
+		{value: "NEVER", want: FailOnNever},
+		{value: "success", wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseFailOn(test.value)
+
+		if (err != nil) != test.wantErr {
+			t.Errorf("ParseFailOn(%q) error = %v, wantErr %v", test.value, err, test.wantErr)
+			continue
+		}
+
+		if got != test.want {
+			t.Errorf("ParseFailOn(%q) = %q, want %q", test.value, got, test.want)
+		}
+	}
+}
+
+func TestCheckReportExitCode(t *testing.T) {
+	success := CheckResult{Scope: "PHP", Findings: []Finding{{Severity: SeveritySuccess, RuleID: RulePHPVersion}}}
+	warning := CheckResult{Scope: "Node", Findings: []Finding{{Severity: SeverityWarning, RuleID: RuleNodeEOL}}}
+	failure := CheckResult{Scope: "Go", Findings: []Finding{{Severity: SeverityError, RuleID: RuleGoVersion}}}
+	timedOut := CheckResult{Scope: "Composer", TimedOut: true, Findings: []Finding{{Severity: SeverityWarning, RuleID: RuleModuleTimeout}}}
+	blocked := blockedResult(fakeModule{name: "Composer"}, []string{"PHP"})
+
+	// A BASELINED FINDING NO LONGER FAILS THE RUN.
+	baselined := CheckResult{Scope: "Go", Baselined: failure.Findings}
+
+	tests := []struct {
+		name     string
+		failOn   FailOn
+		canceled bool
+		results  []CheckResult
+		want     int
+	}{
+		{name: "no findings", failOn: FailOnError, want: ExitSuccess},
+		{name: "successes", failOn: FailOnError, results: []CheckResult{success}, want: ExitSuccess},
+		{name: "warning with fail-on error", failOn: FailOnError, results: []CheckResult{success, warning}, want: ExitSuccess},
+		{name: "warning with fail-on warning", failOn: FailOnWarning, results: []CheckResult{success, warning}, want: ExitRequirementsFailed},
+		{name: "error with fail-on error", failOn: FailOnError, results: []CheckResult{failure}, want: ExitRequirementsFailed},
+		{name: "error with default fail-on", results: []CheckResult{failure}, want: ExitRequirementsFailed},
+		{name: "error with fail-on never", failOn: FailOnNever, results: []CheckResult{failure}, want: ExitSuccess},
+		{name: "blocked with fail-on error", failOn: FailOnError, results: []CheckResult{success, blocked}, want: ExitRequirementsFailed},
+		{name: "baselined error", failOn: FailOnWarning, results: []CheckResult{baselined}, want: ExitSuccess},
+		{name: "timed out", failOn: FailOnNever, results: []CheckResult{timedOut, blocked}, want: ExitAborted},
+		{name: "canceled", failOn: FailOnNever, canceled: true, results: []CheckResult{success}, want: ExitAborted},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			report := CheckReport{Results: test.results, FailOn: test.failOn, Canceled: test.canceled}
+
+			if got := report.ExitCode(); got != test.want {
+				t.Errorf("ExitCode() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCheckResultStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		result CheckResult
+		want   Status
+	}{
+		{name: "empty", result: CheckResult{}, want: StatusSuccess},
+		{name: "warning", result: CheckResult{Findings: []Finding{{Severity: SeverityWarning}, {Severity: SeveritySuccess}}}, want: StatusWarning},
+		{name: "error", result: CheckResult{Findings: []Finding{{Severity: SeverityWarning}, {Severity: SeverityError}}}, want: StatusError},
+		{name: "blocked", result: blockedResult(fakeModule{name: "Composer"}, []string{"PHP"}), want: StatusBlocked},
+		{name: "timed out", result: CheckResult{TimedOut: true, BlockedBy: []string{"PHP"}}, want: StatusTimedOut},
+	}
+
+	for _, test := range tests {
+		if got := test.result.Status(); got != test.want {
+			t.Errorf("%s: Status() = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestRenderJSON(t *testing.T) {
+	startedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	report := CheckReport{
+		Version:   "1.0.0",
+		StartedAt: startedAt,
+		EndedAt:   startedAt.Add(1500 * time.Millisecond),
+		Results: []CheckResult{
+			{Scope: "PHP", Duration: 20 * time.Millisecond, Findings: []Finding{
+				{Severity: SeveritySuccess, RuleID: RulePHPVersion, Subject: "php", Message: "Installed PHP."},
+				{Severity: SeverityError, RuleID: RulePHPExtension, Subject: "ext-intl", Message: "Missing extension intl."},
+			}},
+			{Scope: "Node", Findings: []Finding{{Severity: SeverityWarning, RuleID: RuleNodeEOL, Subject: "node", Message: "Node.js 16 is EOL."}}},
+		},
+	}
+
+	var buffer bytes.Buffer
+
+	if err := renderJSON(&buffer, report); err != nil {
+		t.Fatal(err)
+	}
+
+	// EMPTY LISTS ARE WRITTEN AS [], SO CONSUMERS CAN ITERATE THEM WITHOUT A NULL CHECK.
+	if !strings.Contains(buffer.String(), `"warnings": []`) {
+		t.Errorf("empty findings are not encoded as []:\n%s", buffer.String())
+	}
+
+	var document JSONReport
+
+	if err := json.Unmarshal(buffer.Bytes(), &document); err != nil {
+		t.Fatal(err)
+	}
+
+	if document.SchemaVersion != JSONSchemaVersion || document.Tool.Version != "1.0.0" || document.DurationMs != 1500 {
+		t.Errorf("header = schema %d, version %q, %d ms", document.SchemaVersion, document.Tool.Version, document.DurationMs)
+	}
+
+	if document.Status != StatusError || document.ExitCode != report.ExitCode() {
+		t.Errorf("status = %q, exit code %d, want error and %d", document.Status, document.ExitCode, report.ExitCode())
+	}
+
+	if document.Summary != (JSONSummary{Errors: 1, Warnings: 1, Successes: 1}) {
+		t.Errorf("summary = %+v, want one finding of every severity", document.Summary)
+	}
+
+	if len(document.Results) != 2 {
+		t.Fatalf("results = %+v, want 2", document.Results)
+	}
+
+	php := document.Results[0]
+
+	if php.Scope != "PHP" || php.Status != StatusError || php.DurationMs != 20 || len(php.Errors) != 1 || php.Errors[0].Subject != "ext-intl" {
+		t.Errorf("PHP result = %+v", php)
+	}
+}
+
+// writeManifest WRITES A composer.json REQUIRING ext-intl ON LINE 3 TO THE ROOT OF A NEW REPOSITORY.
+func writeManifest(t *testing.T) string {
+	t.Helper()
+
+	repository := t.TempDir()
+	manifest := filepath.Join(repository, "composer.json")
+
+	if err := os.Mkdir(filepath.Join(repository, ".git"), 0o755); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(manifest, []byte("{\n  \"require\": {\n    \"ext-intl\": \"*\"\n  }\n}\n"), 0o644); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	return manifest
+}
+
+func TestRenderSARIF(t *testing.T) {
+	manifest := writeManifest(t)
+
+	report := CheckReport{Version: "1.0.0", Results: []CheckResult{{Scope: "PHP", Findings: []Finding{
+		{Severity: SeveritySuccess, RuleID: RulePHPVersion, Subject: "php", Message: "Installed PHP."},
+		{Severity: SeverityError, Module: "PHP", RuleID: RulePHPExtension, Subject: "ext-intl", File: manifest, Message: "Missing extension intl."},
+		{Severity: SeverityWarning, Module: "Custom", RuleID: "custom/check", Message: "Custom warning."},
+	}}}}
+
+	var buffer bytes.Buffer
+
+	if err := renderSARIF(&buffer, report); err != nil {
+		t.Fatal(err)
+	}
+
+	var log sarifLog
+
+	if err := json.Unmarshal(buffer.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("version %q with %d runs, want 2.1.0 with one run", log.Version, len(log.Runs))
+	}
+
+	run := log.Runs[0]
+
+	// SUCCESSES ARE NOT RESULTS.
+	if len(run.Results) != 2 {
+		t.Fatalf("results = %+v, want the error and the warning", run.Results)
+	}
+
+	for _, result := range run.Results {
+		if rule := run.Tool.Driver.Rules[result.RuleIndex]; rule.ID != result.RuleID {
+			t.Errorf("result %s points to rule %s", result.RuleID, rule.ID)
+		}
+	}
+
+	extension := run.Results[0]
+
+	if extension.Level != "error" || len(extension.Locations) != 1 {
+		t.Fatalf("extension result = %+v, want an error with a location", extension)
+	}
+
+	location := extension.Locations[0].PhysicalLocation
+
+	if location.ArtifactLocation != (sarifArtifactLocation{URI: "composer.json", URIBaseID: sarifSourceRoot}) {
+		t.Errorf("artifact location = %+v, want composer.json relative to %s", location.ArtifactLocation, sarifSourceRoot)
+	}
+
+	if location.Region == nil || location.Region.StartLine != 3 {
+		t.Errorf("region = %+v, want line 3", location.Region)
+	}
+
+	if custom := run.Results[1]; custom.Level != "warning" || len(custom.Locations) != 0 {
+		t.Errorf("custom result = %+v, want a warning without a location", custom)
+	}
+}
+
+func TestRenderJUnit(t *testing.T) {
+	report := CheckReport{Results: []CheckResult{
+		{Scope: "PHP", Duration: 1500 * time.Millisecond, Findings: []Finding{
+			{Severity: SeveritySuccess, RuleID: RulePHPVersion, Subject: "php", Message: "Installed PHP."},
+			{Severity: SeverityError, RuleID: RulePHPExtension, Subject: "ext-intl", Required: "*", Remediation: "Enable intl.", Message: "Missing extension intl."},
+		}},
+		{Scope: "Node", Findings: []Finding{{Severity: SeverityWarning, RuleID: RuleNodeEOL, Message: "Node.js 16 is EOL."}}},
+	}}
+
+	var buffer bytes.Buffer
+
+	if err := renderJUnit(&buffer, report); err != nil {
+		t.Fatal(err)
+	}
+
+	var suites junitTestSuites
+
+	if err := xml.Unmarshal(buffer.Bytes(), &suites); err != nil {
+		t.Fatal(err)
+	}
+
+	if suites.Tests != 3 || suites.Failures != 1 || len(suites.Suites) != 2 {
+		t.Fatalf("%d tests, %d failures in %d suites, want 3 tests, 1 failure in 2 suites", suites.Tests, suites.Failures, len(suites.Suites))
+	}
+
+	php := suites.Suites[0]
+
+	if php.Name != "PHP" || php.Time != "1.500" || php.Failures != 1 {
+		t.Errorf("PHP suite = %s in %s with %d failures", php.Name, php.Time, php.Failures)
+	}
+
+	failure := php.TestCases[1].Failure
+
+	if php.TestCases[1].Name != RulePHPExtension+": ext-intl" || failure == nil || failure.Type != RulePHPExtension {
+		t.Fatalf("failed test case = %+v, want a %s failure", php.TestCases[1], RulePHPExtension)
+	}
+
+	if failure.Body != "Required: *\nRemediation: Enable intl." {
+		t.Errorf("failure body = %q", failure.Body)
+	}
+
+	// WARNINGS PASS, THEIR MESSAGE IS KEPT AS OUTPUT OF THE TEST CASE.
+	if warning := suites.Suites[1].TestCases[0]; warning.Failure != nil || warning.SystemOut != "WARNING: Node.js 16 is EOL." {
+		t.Errorf("warning test case = %+v", warning)
+	}
+}
+
+func TestRenderMarkdownAndHTML(t *testing.T) {
+	report := CheckReport{Results: []CheckResult{
+		{Scope: "PHP", Findings: []Finding{
+			{Severity: SeveritySuccess, RuleID: RulePHPVersion, Subject: "php", Message: "Installed PHP."},
+			{Severity: SeverityError, RuleID: RulePHPExtension, Subject: "ext-intl", Message: "Missing <intl> | see php.ini"},
+		}},
+		{Scope: "Node", Findings: []Finding{{Severity: SeveritySuccess, RuleID: RuleNodeVersion, Message: "Installed Node.js."}}},
+	}}
+
+	tests := []struct {
+		name     string
+		renderer ReportRenderer
+		want     []string
+		wantNot  []string
+	}{
+		{
+			name:     "markdown",
+			renderer: renderMarkdown,
+			want: []string{
+				"<details open>\n<summary><strong>" + utils.CrossMark,
+				"| `" + RulePHPExtension + "` | ext-intl | – | – | Missing &lt;intl&gt; \\| see php.ini |",
+				"<details>\n<summary><strong>" + utils.CheckMark,
+			},
+		},
+		{
+			name:     "HTML",
+			renderer: renderHTML,
+			want:     []string{"<details open>", "Missing &lt;intl&gt; | see php.ini"},
+			wantNot:  []string{"<intl>"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+
+			if err := test.renderer(&buffer, report); err != nil {
+				t.Fatal(err)
+			}
+
+			output := buffer.String()
+
+			// ERRORS ARE LISTED BEFORE SUCCESSES.
+			if strings.Index(output, RulePHPExtension) > strings.Index(output, RulePHPVersion) {
+				t.Errorf("the error is not listed first:\n%s", output)
+			}
+
+			for _, want := range test.want {
+				if !strings.Contains(output, want) {
+					t.Errorf("output does not contain %q:\n%s", want, output)
+				}
+			}
+
+			for _, wantNot := range test.wantNot {
+				if strings.Contains(output, wantNot) {
+					t.Errorf("output contains %q:\n%s", wantNot, output)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderCIAnnotations(t *testing.T) {
+	manifest := writeManifest(t)
+
+	report := CheckReport{Results: []CheckResult{{Scope: "PHP", Findings: []Finding{
+		{Severity: SeveritySuccess, Module: "PHP", RuleID: RulePHPVersion, Subject: "php", Message: "Installed PHP."},
+		{Severity: SeverityError, Module: "PHP", RuleID: RulePHPExtension, Subject: "ext-intl", File: manifest, Message: "Missing intl,\nat 100%"},
+		{Severity: SeverityWarning, Module: "Go", RuleID: RuleGoEOL, Message: "Go 1.20 is EOL."},
+	}}}}
+
+	var github bytes.Buffer
+
+	if err := renderGitHub(&github, report); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"::error file=composer.json,line=3,title=PHP%3A " + RulePHPExtension + "::Missing intl,%0Aat 100%25\n",
+		"::warning title=Go%3A " + RuleGoEOL + "::Go 1.20 is EOL.\n",
+	} {
+		if !strings.Contains(github.String(), want) {
+			t.Errorf("GitHub output does not contain %q:\n%s", want, github.String())
+		}
+	}
+
+	var gitlab bytes.Buffer
+
+	if err := renderGitLabCodeQuality(&gitlab, report); err != nil {
+		t.Fatal(err)
+	}
+
+	var issues []codeQualityIssue
+
+	if err := json.Unmarshal(gitlab.Bytes(), &issues); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []codeQualityLocation{{Path: "composer.json", Lines: codeQualityLines{Begin: 3}}, {Path: ".", Lines: codeQualityLines{Begin: 1}}}
+
+	if len(issues) != len(want) {
+		t.Fatalf("issues = %+v, want %d", issues, len(want))
+	}
+
+	for i := range want {
+		if issues[i].Location != want[i] {
+			t.Errorf("issue %d location = %+v, want %+v", i, issues[i].Location, want[i])
+		}
+	}
+
+	if issues[0].Severity != "major" || issues[1].Severity != "minor" {
+		t.Errorf("severities = %s and %s, want major and minor", issues[0].Severity, issues[1].Severity)
+	}
+}
+
+func TestTemplateRenderer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	template := `{{ .Status }} {{ .Summary.Errors }}/{{ .Summary.Warnings }}
+{{- range groupBy "severity" .Findings }}
+{{ upper .Key }}:{{ range .Findings }} {{ .Subject }}{{ end }}
+{{- end }}
+`
+
+	if err := os.WriteFile(path, []byte(template), 0o644); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	renderer, err := newTemplateRenderer(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := CheckReport{Results: []CheckResult{
+		{Scope: "PHP", Findings: []Finding{
+			{Severity: SeveritySuccess, RuleID: RulePHPVersion, Subject: "php"},
+			{Severity: SeverityWarning, RuleID: RulePHPExtensionDeprecated, Subject: "ext-imap"},
+		}},
+		{Scope: "Composer", Findings: []Finding{{Severity: SeverityError, RuleID: RuleComposerDependency, Subject: "laravel/framework"}}},
+	}}
+
+	var buffer bytes.Buffer
+
+	if err := renderer(&buffer, report); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "error 1/1\nERROR: laravel/framework\nWARNING: ext-imap\nSUCCESS: php\n"
+
+	if buffer.String() != want {
+		t.Errorf("output = %q, want %q", buffer.String(), want)
+	}
+
+	if _, err := groupFindings("scope", nil); err == nil {
+		t.Error("groupFindings() with an unknown key succeeded")
+	}
+
+	if _, err := newTemplateRenderer(""); err == nil {
+		t.Error("newTemplateRenderer() without a template succeeded")
+	}
+}
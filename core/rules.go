@@ -0,0 +1,316 @@
+package core
+
+import "sort"
+
+// RULE IDS ARE STABLE AND MUST NOT BE RENAMED ONCE RELEASED.
+const (
+	RulePHPInstalled             = "php/installed"
+	RulePHPVersion               = "php/version"
+	RulePHPEOL                   = "php/eol"
+	RulePHPExtension             = "php/extension"
+	RulePHPExtensionDeprecated   = "php/extension-deprecated"
+	RulePHPExtensionExperimental = "php/extension-experimental"
+	RulePHPExtensionsUnreadable  = "php/extensions-unreadable"
+
+	RuleComposerInstalled  = "composer/installed"
+	RuleComposerManifest   = "composer/manifest"
+	RuleComposerLockOnly   = "composer/lock-without-manifest"
+	RuleComposerDependency = "composer/dependency"
+
+	RuleNodeInstalled = "node/installed"
+	RuleNodeVersion   = "node/version"
+	RuleNodeEOL       = "node/eol"
+	RuleNodeManifest  = "node/manifest"
+
+	RulePackageManifest   = "package/manifest"
+	RulePackageLockOnly   = "package/lock-without-manifest"
+	RulePackageEngine     = "package/engine"
+	RulePackageDependency = "package/dependency"
+
+	RuleGoInstalled          = "go/installed"
+	RuleGoManifest           = "go/manifest"
+	RuleGoVersion            = "go/version"
+	RuleGoEOL                = "go/eol"
+	RuleGoVersionUnspecified = "go/version-unspecified"
+	RuleGoModule             = "go/module"
+
+	RuleToolVersion = "tool/version"
+
+	RuleCustomInstalled = "custom/installed"
+	RuleCustomVersion   = "custom/version"
+	RuleCustomEOL       = "custom/eol"
+
+	RulePluginFailed  = "plugin/failed"
+	RulePluginFinding = "plugin/finding"
+
+	RuleModuleTimeout = "preflight/timeout"
+	RuleModuleBlocked = "preflight/blocked"
+)
+
+// Rule DESCRIBES A REQUIREMENT THAT MODULES CHECK.
+type Rule struct {
+	// ID IS THE STABLE IDENTIFIER USED BY FINDINGS.
+	ID string
+
+	// Name IS A SHORT PascalCase NAME OF THE RULE.
+	Name string
+
+	// Description EXPLAINS WHAT THE RULE VERIFIES.
+	Description string
+
+	// Help EXPLAINS HOW TO RESOLVE A FAILING RULE.
+	Help string
+
+	// Level IS THE SEVERITY USED WHEN THE RULE FAILS.
+	Level Severity
+
+	// Dependency IS SET WHEN THE RULE DESCRIBES A SINGLE DECLARED DEPENDENCY RATHER THAN A TOOL.
+	Dependency bool
+
+	// Blocking IS SET WHEN A FAILING RULE MEANS THE TOOL OF THE MODULE IS NOT USABLE,
+	// SO THE MODULES DEPENDING ON IT CANNOT BE CHECKED AND ARE REPORTED AS BLOCKED.
+	Blocking bool
+}
+
+// ruleCatalog CONTAINS ALL BUILT-IN RULES.
+var ruleCatalog = map[string]Rule{
+	RulePHPInstalled: {
+		Name:        "PhpInstalled",
+		Description: "PHP is installed and available in PATH.",
+		Help:        "Install PHP from https://www.php.net/downloads.",
+		Level:       SeverityError,
+		Blocking:    true,
+	},
+	RulePHPVersion: {
+		Name:        "PhpVersion",
+		Description: "The installed PHP version satisfies the constraint in composer.json.",
+		Help:        "Install a PHP version matching the \"php\" requirement in composer.json.",
+		Level:       SeverityError,
+	},
+	RulePHPEOL: {
+		Name:        "PhpEndOfLife",
+		Description: "The installed PHP version still receives security updates.",
+		Help:        "Upgrade to a supported PHP version.",
+		Level:       SeverityWarning,
+	},
+	RulePHPExtension: {
+		Name:        "PhpExtension",
+		Description: "Every ext-* requirement in composer.json is enabled.",
+		Help:        "Install or enable the extension in php.ini.",
+		Level:       SeverityError,
+		Dependency:  true,
+	},
+	RulePHPExtensionDeprecated: {
+		Name:        "PhpExtensionDeprecated",
+		Description: "Required PHP extensions are not deprecated.",
+		Help:        "Remove or replace the deprecated extension.",
+		Level:       SeverityWarning,
+		Dependency:  true,
+	},
+	RulePHPExtensionExperimental: {
+		Name:        "PhpExtensionExperimental",
+		Description: "Required PHP extensions are not experimental.",
+		Help:        "Use experimental extensions with caution.",
+		Level:       SeverityWarning,
+		Dependency:  true,
+	},
+	RulePHPExtensionsUnreadable: {
+		Name:        "PhpExtensionsUnreadable",
+		Description: "The installed PHP extensions can be listed with php -m.",
+		Help:        "Ensure php -m runs successfully.",
+		Level:       SeverityError,
+		Blocking:    true,
+	},
+	RuleComposerInstalled: {
+		Name:        "ComposerInstalled",
+		Description: "Composer is installed and available in PATH.",
+		Help:        "Install Composer from https://getcomposer.org.",
+		Level:       SeverityError,
+		Blocking:    true,
+	},
+	RuleComposerManifest: {
+		Name:        "ComposerManifest",
+		Description: "composer.json exists and can be parsed.",
+		Help:        "Add a valid composer.json to the project.",
+		Level:       SeverityError,
+	},
+	RuleComposerLockOnly: {
+		Name:        "ComposerLockWithoutManifest",
+		Description: "composer.lock is accompanied by composer.json.",
+		Help:        "Commit composer.json together with composer.lock.",
+		Level:       SeverityWarning,
+	},
+	RuleComposerDependency: {
+		Name:        "ComposerDependency",
+		Description: "Every Composer dependency is installed.",
+		Help:        "Run composer install or composer require for the missing dependency.",
+		Level:       SeverityError,
+		Dependency:  true,
+	},
+	RuleNodeInstalled: {
+		Name:        "NodeInstalled",
+		Description: "Node.js is installed and available in PATH.",
+		Help:        "Install Node.js from https://nodejs.org.",
+		Level:       SeverityError,
+		Blocking:    true,
+	},
+	RuleNodeVersion: {
+		Name:        "NodeVersion",
+		Description: "The installed Node.js version satisfies engines.node in package.json.",
+		Help:        "Install a Node.js version matching engines.node in package.json.",
+		Level:       SeverityError,
+	},
+	RuleNodeEOL: {
+		Name:        "NodeEndOfLife",
+		Description: "The installed Node.js version still receives security updates.",
+		Help:        "Upgrade to a supported Node.js version.",
+		Level:       SeverityWarning,
+	},
+	RuleNodeManifest: {
+		Name:        "NodeManifest",
+		Description: "package.json can be parsed.",
+		Help:        "Fix the syntax of package.json.",
+		Level:       SeverityWarning,
+	},
+	RulePackageManifest: {
+		Name:        "PackageManifest",
+		Description: "package.json exists when node_modules is present.",
+		Help:        "Add a valid package.json to the project.",
+		Level:       SeverityError,
+	},
+	RulePackageLockOnly: {
+		Name:        "PackageLockWithoutManifest",
+		Description: "JavaScript lock files are accompanied by package.json.",
+		Help:        "Commit package.json together with the lock file.",
+		Level:       SeverityWarning,
+	},
+	RulePackageEngine: {
+		Name:        "PackageEngine",
+		Description: "The installed package manager satisfies engines in package.json.",
+		Help:        "Install a package manager version matching engines in package.json.",
+		Level:       SeverityWarning,
+	},
+	RulePackageDependency: {
+		Name:        "PackageDependency",
+		Description: "Every package in package.json is installed in node_modules.",
+		Help:        "Run the install command of your package manager.",
+		Level:       SeverityError,
+		Dependency:  true,
+	},
+	RuleGoInstalled: {
+		Name:        "GoInstalled",
+		Description: "Go is installed and available in PATH.",
+		Help:        "Install Go from https://go.dev/dl.",
+		Level:       SeverityError,
+		Blocking:    true,
+	},
+	RuleGoManifest: {
+		Name:        "GoManifest",
+		Description: "go.mod can be parsed.",
+		Help:        "Fix the syntax of go.mod.",
+		Level:       SeverityError,
+	},
+	RuleGoVersion: {
+		Name:        "GoVersion",
+		Description: "The installed Go version satisfies the go directive in go.mod.",
+		Help:        "Install a Go version matching the go directive in go.mod.",
+		Level:       SeverityError,
+	},
+	RuleGoEOL: {
+		Name:        "GoEndOfLife",
+		Description: "The installed Go version still receives security updates.",
+		Help:        "Upgrade to a supported Go version.",
+		Level:       SeverityWarning,
+	},
+	RuleGoVersionUnspecified: {
+		Name:        "GoVersionUnspecified",
+		Description: "go.mod declares a go directive.",
+		Help:        "Add a go directive to go.mod.",
+		Level:       SeverityWarning,
+	},
+	RuleGoModule: {
+		Name:        "GoModule",
+		Description: "Every module required in go.mod is available.",
+		Help:        "Run go mod download or go get for the missing module.",
+		Level:       SeverityError,
+		Dependency:  true,
+	},
+	RuleToolVersion: {
+		Name:        "ToolVersion",
+		Description: "The installed tool satisfies the extra version constraint of the project configuration.",
+		Help:        "Install a version of the tool matching its constraint under \"tools\" in .preflight.yml.",
+		Level:       SeverityError,
+	},
+	RuleCustomInstalled: {
+		Name:        "CustomToolInstalled",
+		Description: "The tool of a custom module is installed and reports its version.",
+		Help:        "Install the tool declared under \"customModules\" in .preflight.yml and make sure it is in PATH.",
+		Level:       SeverityError,
+		Blocking:    true,
+	},
+	RuleCustomVersion: {
+		Name:        "CustomToolVersion",
+		Description: "The tool of a custom module satisfies its version constraint.",
+		Help:        "Install a version of the tool matching the \"constraint\" of its custom module.",
+		Level:       SeverityError,
+	},
+	RuleCustomEOL: {
+		Name:        "CustomToolEndOfLife",
+		Description: "The tool of a custom module is not an End-of-Life version.",
+		Help:        "Upgrade the tool to a version that is not listed under \"eol\" of its custom module.",
+		Level:       SeverityWarning,
+	},
+	RulePluginFailed: {
+		Name:        "PluginFailed",
+		Description: "The plugin described itself and completed its check with a valid response.",
+		Help:        "Run `preflight plugin test <name>` to find out why the plugin crashed or answered with an invalid response.",
+		Level:       SeverityError,
+		Blocking:    true,
+	},
+	RulePluginFinding: {
+		Name:        "PluginFinding",
+		Description: "A finding reported by a plugin without a rule ID.",
+		Help:        "Ask the plugin author to report a ruleId with every finding.",
+		Level:       SeverityError,
+	},
+	RuleModuleTimeout: {
+		Name:        "ModuleTimeout",
+		Description: "The module verified all requirements before its timeout expired.",
+		Help:        "Increase --timeout or --module-timeout, or --command-timeout when a single command is slow.",
+		Level:       SeverityError,
+	},
+	RuleModuleBlocked: {
+		Name:        "ModuleBlocked",
+		Description: "The modules this module depends on passed, so its requirements could be checked.",
+		Help:        "Resolve the errors of the prerequisite module first, the blocked module is checked on the next run.",
+		Level:       SeverityError,
+	},
+}
+
+// LookupRule RETURNS THE RULE WITH THE GIVEN ID, FALLING BACK TO A GENERIC RULE FOR UNKNOWN IDS.
+func LookupRule(id string) Rule {
+	rule, ok := ruleCatalog[id]
+
+	if !ok {
+		return Rule{ID: id, Name: id, Description: id, Level: SeverityError}
+	}
+
+	rule.ID = id
+
+	return rule
+}
+
+// Rules RETURNS ALL BUILT-IN RULES SORTED BY ID.
+func Rules() []Rule {
+	rules := make([]Rule, 0, len(ruleCatalog))
+
+	for id := range ruleCatalog {
+		rules = append(rules, LookupRule(id))
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+
+	return rules
+}
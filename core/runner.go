@@ -0,0 +1,188 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"io"
+	"os"
+	"time"
+)
+
+// RunnerOptions CONFIGURES WHICH MODULES A Runner CHECKS AND HOW.
+type RunnerOptions struct {
+	// Version OF PreFlight, INCLUDED IN MACHINE-READABLE REPORTS.
+	Version string
+
+	// ProjectDir IS THE PROJECT TO CHECK, THE WORKING DIRECTORY IS USED WHEN EMPTY.
+	ProjectDir string
+
+	// Modules LISTS THE NAMES OF THE MODULES TO CHECK, ALL REGISTERED MODULES ARE CHECKED WHEN EMPTY.
+	Modules []string
+
+	// EcosystemsOnly SKIPS MODULES WITHOUT A Detector, SUCH AS CUSTOM MODULES THAT APPLY TO A REPOSITORY AS A WHOLE.
+	EcosystemsOnly bool
+
+	// Progress RECEIVES THE PROGRESS OUTPUT, IT IS DISCARDED WHEN NIL.
+	Progress io.Writer
+
+	// OnProgress IS CALLED WHENEVER A MODULE STARTS, COMPLETES OR IS SKIPPED, FOR CALLERS THAT SHOW THEIR OWN PROGRESS.
+	OnProgress func(ProgressEvent)
+
+	// Timeout LIMITS THE WHOLE RUN, ZERO MEANS UNLIMITED.
+	Timeout time.Duration
+
+	// Jobs IS THE MAXIMUM NUMBER OF MODULES RUNNING CONCURRENTLY, ZERO RUNS ALL MODULES AT ONCE.
+	Jobs int
+
+	// ModuleTimeouts LIMITS EACH MODULE BY ITS LOWERCASE NAME, THE "*" KEY APPLIES TO ALL OTHER MODULES.
+	ModuleTimeouts map[string]time.Duration
+
+	// CommandTimeout LIMITS EVERY EXTERNAL COMMAND, ZERO MEANS UNLIMITED.
+	CommandTimeout time.Duration
+
+	// FailOn IS THE LOWEST SEVERITY THAT MAKES THE RUN FAIL, SEE ParseFailOn.
+	FailOn FailOn
+
+	// Sort IS THE ORDER OF THE REPORTED MODULES, SortByPriority WHEN EMPTY.
+	Sort SortType
+
+	// ModuleOrder LISTS MODULES THAT ARE REPORTED FIRST, IN THIS ORDER, BEFORE Sort APPLIES TO THE REST.
+	ModuleOrder []string
+
+	// Baseline FILE WITH KNOWN FINDINGS TO HIDE, NO BASELINE IS USED WHEN EMPTY.
+	Baseline string
+
+	// WriteBaseline FILE THAT RECEIVES ALL CURRENT ERRORS AND WARNINGS AS THE NEW BASELINE.
+	WriteBaseline string
+
+	// Cache SERVES THE FINDINGS OF A CacheableModule FROM CacheDir WHEN NONE OF ITS INPUTS CHANGED.
+	Cache bool
+
+	// History RECORDS EVERY COMPLETED RUN IN HistoryDir, SEE AnalyzeHistory.
+	History bool
+
+	// Environment IS EVALUATED INSTEAD OF THIS MACHINE WHEN SET, SEE WithEnvironment.
+	// SUCH RUNS ARE NEVER CACHED NOR RECORDED IN THE HISTORY, AS THEY DO NOT DESCRIBE THIS MACHINE.
+	Environment *Environment
+}
+
+// Runner CHECKS THE MODULES OF A REGISTRY AND RETURNS A STRUCTURED REPORT.
+type Runner struct {
+	registry *Registry
+	options  RunnerOptions
+}
+
+// NewRunner CREATES A RUNNER FOR THE MODULES OF A REGISTRY.
+func NewRunner(registry *Registry, options RunnerOptions) *Runner {
+	return &Runner{registry: registry, options: options}
+}
+
+// Run CHECKS THE SELECTED MODULES AND RETURNS THE REPORT.
+// AN ERROR IS ONLY RETURNED WHEN THE RUN COULD NOT START OR ITS BASELINE COULD NOT BE WRITTEN,
+// FAILED, CANCELED AND TIMED OUT CHECKS ARE PART OF THE REPORT, SEE CheckReport.ExitCode.
+func (r *Runner) Run(ctx context.Context) (CheckReport, error) {
+	options := r.options
+	progress := options.Progress
+
+	if progress == nil {
+		progress = io.Discard
+	}
+
+	// LIVE PROGRESS REWRITES LINES, WHICH IS ONLY POSSIBLE ON A TERMINAL.
+	live := false
+
+	if file, ok := progress.(*os.File); ok {
+		live = utils.IsTerminal(file)
+	}
+
+	// LOAD THE BASELINE BEFORE ANYTHING IS STARTED, SO A BROKEN FILE FAILS FAST.
+	var baseline *Baseline
+
+	if options.Baseline != "" && options.WriteBaseline == "" {
+		loaded, err := LoadBaseline(options.Baseline)
+
+		if err != nil {
+			return CheckReport{}, err
+		}
+
+		baseline = &loaded
+	}
+
+	// RESOLVE MODULE DEPENDENCIES BEFORE ANYTHING IS STARTED.
+	selected, err := r.registry.Select(options.Modules...)
+
+	if err != nil {
+		return CheckReport{}, err
+	}
+
+	// MODULES OF ECOSYSTEMS THE PROJECT DOES NOT USE ARE LEFT OUT, AND REPORTED AS SUCH.
+	modules, detections := detectModules(OrderModules(SortModules(selected, options.Sort), options.ModuleOrder), options.ProjectDir, options.EcosystemsOnly)
+	prerequisites, err := buildDependencyGraph(modules)
+
+	if err != nil {
+		return CheckReport{}, err
+	}
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	if options.CommandTimeout > 0 {
+		ctx = utils.WithCommandTimeout(ctx, options.CommandTimeout)
+	}
+
+	if options.ProjectDir != "" {
+		ctx = utils.WithProjectDir(ctx, options.ProjectDir)
+	}
+
+	if options.Environment != nil {
+		ctx = WithEnvironment(ctx, options.Environment)
+		options.Cache, options.History = false, false
+	}
+
+	// WRITING A BASELINE RUNS EVERY MODULE, SO FINDINGS OF OTHERWISE BLOCKED MODULES ARE ACCEPTED AS WELL.
+	if options.WriteBaseline != "" {
+		prerequisites = make([][]int, len(modules))
+	}
+
+	ow := utils.NewOutputWriterTo(progress)
+
+	if env := options.Environment; env != nil {
+		ow.Printf("%sEvaluating against the %s/%s environment exported at %s, no commands are run.%s\n\n",
+			utils.Dim, env.OS, env.Arch, env.ExportedAt.Local().Format(endedAtLayout), utils.Reset)
+	}
+
+	report := collectResults(ctx, ow, live, modules, prerequisites, baseline, options)
+	report.Version = options.Version
+	report.Detections = detections
+	report.FailOn = options.FailOn
+	report.Selected = make([]string, 0, len(selected))
+
+	for _, module := range selected {
+		report.Selected = append(report.Selected, module.Name())
+	}
+
+	// A NEWLY WRITTEN BASELINE IS APPLIED RIGHT AWAY, SO THE RUN THAT ACCEPTS THE FINDINGS PASSES.
+	if options.WriteBaseline != "" {
+		written := NewBaseline(report)
+
+		if err := written.Write(options.WriteBaseline); err != nil {
+			return report, fmt.Errorf("unable to write the baseline: %w", err)
+		}
+
+		ow.Printf("%sWrote %d known findings to %s%s\n\n", utils.Dim, len(written.Findings), options.WriteBaseline, utils.Reset)
+		written.Apply(&report)
+	}
+
+	// A CANCELED RUN DID NOT CHECK EVERYTHING, RECORDING IT WOULD SHOW REQUIREMENTS AS REMOVED.
+	if options.History && !report.Canceled {
+		if err := AppendHistory(options.ProjectDir, NewHistoryEntry(report, options.ProjectDir)); err != nil {
+			ow.Printf("%s%v%s\n\n", utils.Yellow, err, utils.Reset)
+		}
+	}
+
+	return report, nil
+}
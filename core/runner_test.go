@@ -0,0 +1,273 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeModule REPORTS FIXED FINDINGS AND COUNTS HOW OFTEN IT RAN.
+type fakeModule struct {
+	name      string
+	dependsOn []string
+	findings  []Finding
+	runs      *atomic.Int32
+}
+
+func (m fakeModule) Name() string {
+	return m.name
+}
+
+func (m fakeModule) CheckRequirements(_ context.Context) []Finding {
+	if m.runs != nil {
+		m.runs.Add(1)
+	}
+
+	return m.findings
+}
+
+func (m fakeModule) DependsOn() []string {
+	return m.dependsOn
+}
+
+// slowModule IS A fakeModule THAT TAKES delay TO COMPLETE AND TRACKS HOW MANY MODULES RUN AT THE SAME TIME.
+type slowModule struct {
+	fakeModule
+	delay   time.Duration
+	running *atomic.Int32
+	peak    *atomic.Int32
+}
+
+func (m slowModule) CheckRequirements(ctx context.Context) []Finding {
+	running := m.running.Add(1)
+	defer m.running.Add(-1)
+
+	for {
+		if peak := m.peak.Load(); running <= peak || m.peak.CompareAndSwap(peak, running) {
+			break
+		}
+	}
+
+	time.Sleep(m.delay)
+
+	return m.fakeModule.CheckRequirements(ctx)
+}
+
+// stuckModule VERIFIES ITS FIRST REQUIREMENT AND THEN WAITS UNTIL IT IS STOPPED.
+type stuckModule struct{}
+
+func (m stuckModule) Name() string {
+	return "Composer"
+}
+
+func (m stuckModule) Requirements(_ context.Context) []string {
+	return []string{"laravel/framework", "guzzlehttp/guzzle", "symfony/console"}
+}
+
+func (m stuckModule) CheckRequirements(ctx context.Context) []Finding {
+	<-ctx.Done()
+
+	return []Finding{{Severity: SeveritySuccess, RuleID: RuleComposerDependency, Subject: "laravel/framework", Message: "Installed."}}
+}
+
+// runModules CHECKS THE GIVEN MODULES WITH A REGISTRY OF THEIR OWN.
+func runModules(t *testing.T, ctx context.Context, options RunnerOptions, modules ...Module) CheckReport {
+	t.Helper()
+
+	registry := NewRegistry()
+
+	for _, module := range modules {
+		if err := registry.Register("", module); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	options.ProjectDir = t.TempDir()
+	report, err := NewRunner(registry, options).Run(ctx)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return report
+}
+
+func TestRegistriesAreIndependent(t *testing.T) {
+	first, second := NewRegistry(), NewRegistry()
+
+	for _, registry := range []*Registry{first, second} {
+		if err := registry.Register("", fakeModule{name: "PHP"}); err != nil {
+			t.Fatalf("Register() on a new registry = %v", err)
+		}
+	}
+
+	if err := first.Register("php", fakeModule{name: "Other"}); err == nil {
+		t.Error("Register() of a duplicate name succeeded")
+	}
+
+	if err := second.Register("", fakeModule{name: "Custom"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := first.Names(); !slices.Equal(got, []string{"php"}) {
+		t.Errorf("first.Names() = %v, want [php]", got)
+	}
+
+	if got := second.Names(); !slices.Equal(got, []string{"custom", "php"}) {
+		t.Errorf("second.Names() = %v, want [custom php]", got)
+	}
+
+	if _, err := first.Select("custom"); err == nil {
+		t.Error("first.Select(custom) found a module of the other registry")
+	}
+}
+
+func TestRunnerJobs(t *testing.T) {
+	success := []Finding{{Severity: SeveritySuccess, RuleID: RulePHPVersion, Message: "Installed."}}
+
+	for _, jobs := range []int{1, 2, 0} {
+		var running, peak atomic.Int32
+
+		// THE FIRST MODULE IN PRIORITY ORDER FINISHES LAST.
+		report := runModules(t, context.Background(), RunnerOptions{Jobs: jobs},
+			slowModule{fakeModule: fakeModule{name: "Go", findings: success}, delay: time.Millisecond, running: &running, peak: &peak},
+			slowModule{fakeModule: fakeModule{name: "Node", findings: success}, delay: 5 * time.Millisecond, running: &running, peak: &peak},
+			slowModule{fakeModule: fakeModule{name: "Composer", findings: success}, delay: 10 * time.Millisecond, running: &running, peak: &peak},
+			slowModule{fakeModule: fakeModule{name: "PHP", findings: success}, delay: 20 * time.Millisecond, running: &running, peak: &peak},
+			fakeModule{name: "Empty"},
+		)
+
+		var scopes []string
+
+		for _, result := range report.Results {
+			scopes = append(scopes, result.Scope)
+		}
+
+		// MODULES WITHOUT FINDINGS ARE LEFT OUT OF THE REPORT.
+		if want := []string{"PHP", "Composer", "Node", "Go"}; !slices.Equal(scopes, want) {
+			t.Errorf("jobs %d: scopes = %v, want %v", jobs, scopes, want)
+		}
+
+		if limit := int32(jobs); jobs > 0 && peak.Load() > limit {
+			t.Errorf("jobs %d: %d modules ran at the same time", jobs, peak.Load())
+		}
+	}
+}
+
+func TestRunnerCanceled(t *testing.T) {
+	var runs atomic.Int32
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report := runModules(t, ctx, RunnerOptions{Jobs: 1}, fakeModule{name: "PHP", runs: &runs})
+
+	if !report.Canceled || runs.Load() != 0 {
+		t.Errorf("canceled = %v after %d runs, want a canceled report without runs", report.Canceled, runs.Load())
+	}
+}
+
+func TestRunnerModuleTimeout(t *testing.T) {
+	var runs atomic.Int32
+
+	options := RunnerOptions{ModuleTimeouts: map[string]time.Duration{"composer": 10 * time.Millisecond}}
+	report := runModules(t, context.Background(), options,
+		stuckModule{},
+		fakeModule{name: "PHP", findings: []Finding{{Severity: SeveritySuccess, RuleID: RulePHPVersion}}, runs: &runs},
+	)
+
+	if len(report.Results) != 2 || runs.Load() != 1 {
+		t.Fatalf("results = %+v, want both modules to complete", report.Results)
+	}
+
+	composer := report.Results[1]
+
+	if !composer.TimedOut || composer.Status() != StatusTimedOut || report.Status() != StatusTimedOut {
+		t.Errorf("Composer timed out = %v, status %q, report status %q", composer.TimedOut, composer.Status(), report.Status())
+	}
+
+	// THE PARTIAL FINDINGS ARE KEPT, THE REMAINING REQUIREMENTS ARE NOT VERIFIED.
+	if want := []string{"guzzlehttp/guzzle", "symfony/console"}; !slices.Equal(composer.Unverified, want) {
+		t.Errorf("Unverified = %v, want %v", composer.Unverified, want)
+	}
+
+	if len(composer.Findings) != 2 || composer.Findings[1].RuleID != RuleModuleTimeout {
+		t.Errorf("findings = %+v, want the partial finding and a timeout", composer.Findings)
+	}
+
+	if report.Results[0].TimedOut {
+		t.Error("PHP timed out without a timeout")
+	}
+}
+
+func TestRunnerDependencies(t *testing.T) {
+	success := []Finding{{Severity: SeveritySuccess, RuleID: RulePHPVersion, Message: "Installed PHP."}}
+	notInstalled := []Finding{{Severity: SeverityError, RuleID: RulePHPInstalled, Message: "PHP is not installed."}}
+	missingExtension := []Finding{{Severity: SeverityError, RuleID: RulePHPExtension, Subject: "ext-intl", Message: "Missing extension intl."}}
+
+	tests := []struct {
+		name         string
+		prerequisite []Finding
+		wantRuns     int32
+		wantStatus   Status
+	}{
+		{name: "passing prerequisite", prerequisite: success, wantRuns: 1, wantStatus: StatusSuccess},
+		{name: "unusable prerequisite", prerequisite: notInstalled, wantRuns: 0, wantStatus: StatusBlocked},
+		{name: "failing requirement of the prerequisite", prerequisite: missingExtension, wantRuns: 1, wantStatus: StatusSuccess},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var dependentRuns, transitiveRuns atomic.Int32
+
+			registry := NewRegistry()
+
+			for _, module := range []Module{
+				fakeModule{name: "Composer", dependsOn: []string{"PHP"}, findings: success, runs: &dependentRuns},
+				fakeModule{name: "PHP", findings: test.prerequisite},
+				fakeModule{name: "Plugin", dependsOn: []string{"composer"}, findings: success, runs: &transitiveRuns},
+			} {
+				if err := registry.Register("", module); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			report, err := NewRunner(registry, RunnerOptions{ProjectDir: t.TempDir(), Jobs: 1}).Run(context.Background())
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			statuses := make(map[string]Status, len(report.Results))
+
+			for _, result := range report.Results {
+				statuses[result.Scope] = result.Status()
+			}
+
+			if got := dependentRuns.Load(); got != test.wantRuns {
+				t.Errorf("Composer ran %d times, want %d", got, test.wantRuns)
+			}
+
+			if got := transitiveRuns.Load(); got != test.wantRuns {
+				t.Errorf("Plugin ran %d times, want %d", got, test.wantRuns)
+			}
+
+			if statuses["Composer"] != test.wantStatus || statuses["Plugin"] != test.wantStatus {
+				t.Errorf("statuses = %v, want Composer and Plugin %q", statuses, test.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRunnerRejectsDependencyCycles(t *testing.T) {
+	registry := NewRegistry()
+
+	_ = registry.Register("", fakeModule{name: "A", dependsOn: []string{"B"}})
+	_ = registry.Register("", fakeModule{name: "B", dependsOn: []string{"A"}})
+
+	if _, err := NewRunner(registry, RunnerOptions{ProjectDir: t.TempDir()}).Run(context.Background()); err == nil {
+		t.Error("Run() of a dependency cycle succeeded")
+	}
+}
@@ -0,0 +1,489 @@
+package core
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// THE EVENTS OF THE EVENT STREAM OF A Server.
+const (
+	EventCheckStarted   = "check-started"
+	EventModule         = "module"
+	EventCheckCompleted = "check-completed"
+)
+
+// serverHeartbeat IS HOW OFTEN AN IDLE EVENT STREAM RECEIVES A COMMENT, SO PROXIES AND CLIENTS KEEP IT OPEN.
+const serverHeartbeat = 15 * time.Second
+
+// serverShutdownTimeout IS HOW LONG A STOPPING SERVER WAITS FOR OPEN REQUESTS TO COMPLETE.
+const serverShutdownTimeout = 5 * time.Second
+
+// ServerOptions CONFIGURES THE CHECKS AND DEPENDENCIES A Server EXPOSES.
+type ServerOptions struct {
+	// Runner IS THE CONFIGURATION OF EVERY TRIGGERED CHECK, ITS Modules AND OnProgress ARE SET PER CHECK.
+	Runner RunnerOptions
+
+	// Modules RESOLVES THE MODULES OF A TRIGGERED CHECK FROM THE REQUESTED NAMES, WHICH ARE EMPTY WHEN NONE ARE REQUESTED.
+	// THE REQUESTED NAMES ARE CHECKED AS THEY ARE WHEN NIL.
+	Modules func(requested []string) []string
+
+	// Dependencies LISTS THE DEPENDENCIES OF THE REQUESTED PACKAGE MANAGERS, ALL WHEN NONE ARE REQUESTED.
+	// GetAllDependencies OF THE PROJECT IS USED WHEN NIL.
+	Dependencies func(managers []string) DependencyResult
+
+	// Token IS THE SHARED SECRET EVERY REQUEST MUST PRESENT, NO TOKEN IS REQUIRED WHEN EMPTY.
+	// WITHOUT A TOKEN ONLY REQUESTS FROM THIS MACHINE, ADDRESSED TO localhost OR A LOOPBACK ADDRESS, ARE ACCEPTED.
+	Token string
+
+	// AllowedOrigins LISTS THE ORIGINS OF WEB PAGES, SUCH AS https://dashboard.example.com, THAT MAY CALL THE API.
+	// THEY ARE ONLY ALLOWED TOGETHER WITH A Token, EVERY OTHER CROSS-ORIGIN REQUEST IS REJECTED.
+	AllowedOrigins []string
+}
+
+// ServerCheck DESCRIBES A CHECK TRIGGERED THROUGH A Server.
+type ServerCheck struct {
+	ID        int       `json:"id"`
+	Modules   []string  `json:"modules"`
+	StartedAt time.Time `json:"startedAt"`
+	Running   bool      `json:"running"`
+	Status    Status    `json:"status,omitempty"`
+	ExitCode  int       `json:"exitCode"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ServerModuleEvent IS THE DATA OF A module EVENT, THE PROGRESS OF A MODULE OF THE RUNNING CHECK.
+type ServerModuleEvent struct {
+	Check int `json:"check"`
+	ProgressEvent
+}
+
+// serverEvent IS AN EVENT WAITING TO BE SENT TO A SUBSCRIBED EVENT STREAM.
+type serverEvent struct {
+	name string
+	data []byte
+}
+
+// Server EXPOSES THE CHECKS AND DEPENDENCIES OF A PROJECT OVER HTTP, ONE CHECK RUNS AT A TIME.
+type Server struct {
+	registry *Registry
+	options  ServerOptions
+
+	mu          sync.Mutex
+	checks      sync.WaitGroup
+	nextID      int
+	current     *ServerCheck
+	latest      *CheckReport
+	subscribers map[chan serverEvent]struct{}
+}
+
+// NewServer CREATES A SERVER FOR THE MODULES OF A REGISTRY.
+func NewServer(registry *Registry, options ServerOptions) *Server {
+	return &Server{
+		registry:    registry,
+		options:     options,
+		subscribers: make(map[chan serverEvent]struct{}),
+	}
+}
+
+// Serve ANSWERS REQUESTS ON THE LISTENER UNTIL ctx IS CANCELED, THEN IT STOPS THE RUNNING CHECK AND THE OPEN EVENT STREAMS.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	server := &http.Server{
+		Handler:           s.Handler(ctx),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), serverShutdownTimeout)
+	defer cancel()
+
+	err := server.Shutdown(shutdownCtx)
+
+	s.checks.Wait()
+
+	return err
+}
+
+// Handler RETURNS THE API OF THE SERVER, CHECKS IT TRIGGERS ARE CANCELED WITH ctx.
+// REQUESTS FROM ORIGINS THAT ARE NOT ALLOWED ARE REJECTED, SO A WEB PAGE CANNOT USE THE API ON BEHALF OF THE DEVELOPER.
+//
+//	POST /api/checks          TRIGGERS A CHECK, ?modules=php,go LIMITS IT AND ?wait=true ANSWERS WITH ITS REPORT.
+//	GET  /api/checks/latest   RETURNS THE JSON REPORT OF THE LATEST COMPLETED CHECK.
+//	GET  /api/dependencies    LISTS THE DEPENDENCIES OF THE PROJECT, ?pm=composer,go LIMITS THEM.
+//	GET  /api/events          STREAMS THE PROGRESS OF CHECKS AS SERVER-SENT EVENTS.
+func (s *Server) Handler(ctx context.Context) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /api/checks", func(w http.ResponseWriter, r *http.Request) { s.triggerCheck(ctx, w, r) })
+	mux.HandleFunc("GET /api/checks/latest", s.latestCheck)
+	mux.HandleFunc("GET /api/dependencies", s.dependencies)
+	mux.HandleFunc("GET /api/events", s.events)
+
+	return s.guard(s.authorize(mux))
+}
+
+// guard REJECTS REQUESTS A WEB PAGE OPENED BY THE DEVELOPER OR ANOTHER MACHINE COULD HAVE SENT.
+// WITHOUT A TOKEN THE PEER MUST BE THIS MACHINE AND THE HOST A LOOPBACK NAME, SO A PAGE CANNOT READ THE API BY REBINDING
+// ITS DOMAIN TO 127.0.0.1. A CROSS-ORIGIN REQUEST IS REJECTED UNLESS A TOKEN IS SET AND ITS ORIGIN IS ALLOWED, SUCH
+// ORIGINS RECEIVE CORS HEADERS AND THEIR PREFLIGHT REQUESTS ARE ANSWERED BEFORE THE TOKEN IS CHECKED.
+func (s *Server) guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.options.Token == "" && (!isLoopbackPeer(r.RemoteAddr) || !isLoopbackHost(r.Host)) {
+			writeJSONError(w, http.StatusForbidden, "only requests from localhost are accepted without a token")
+			return
+		}
+
+		origin := r.Header.Get("Origin")
+
+		if origin == "" || isSameOrigin(origin, r.Host) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if s.options.Token == "" || !slices.Contains(s.options.AllowedOrigins, origin) {
+			writeJSONError(w, http.StatusForbidden, "cross-origin requests are not accepted")
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+		w.Header().Add("Vary", "Origin")
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authorize REJECTS REQUESTS WITHOUT THE TOKEN, PASSED AS A BEARER TOKEN OR AS ?token= FOR CLIENTS
+// THAT CANNOT SET HEADERS, SUCH AS EventSource IN A BROWSER.
+func (s *Server) authorize(next http.Handler) http.Handler {
+	if s.options.Token == "" {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		if !ok {
+			token = r.URL.Query().Get("token")
+		}
+
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.options.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="preflight"`)
+			writeJSONError(w, http.StatusUnauthorized, "missing or invalid token")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// triggerCheck STARTS A CHECK OF THE REQUESTED MODULES, A SECOND CHECK IS REFUSED WHILE ONE IS RUNNING.
+func (s *Server) triggerCheck(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	names := splitQueryList(r.URL.Query().Get("modules"))
+
+	if s.options.Modules != nil {
+		names = s.options.Modules(names)
+	}
+
+	if _, err := s.registry.Select(names...); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	check, ok := s.startCheck(names)
+
+	if !ok {
+		writeJSONError(w, http.StatusConflict, fmt.Sprintf("check %d is still running", check.ID))
+		return
+	}
+
+	if wait, _ := strconv.ParseBool(r.URL.Query().Get("wait")); !wait {
+		go func() { _, _ = s.runCheck(ctx, check) }()
+
+		writeJSON(w, http.StatusAccepted, check)
+		return
+	}
+
+	report, err := s.runCheck(ctx, check)
+
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, NewJSONReport(report))
+}
+
+// startCheck MARKS A NEW CHECK AS RUNNING, OR RETURNS THE RUNNING CHECK AND FALSE.
+func (s *Server) startCheck(names []string) (ServerCheck, bool) {
+	s.mu.Lock()
+
+	if s.current != nil {
+		defer s.mu.Unlock()
+		return *s.current, false
+	}
+
+	s.nextID++
+	check := ServerCheck{ID: s.nextID, Modules: append([]string{}, names...), StartedAt: time.Now().UTC(), Running: true}
+	s.current = &check
+	s.checks.Add(1)
+	s.mu.Unlock()
+
+	s.publish(EventCheckStarted, check)
+
+	return check, true
+}
+
+// runCheck RUNS A STARTED CHECK, PUBLISHES THE PROGRESS OF ITS MODULES AND KEEPS ITS REPORT AS THE LATEST.
+func (s *Server) runCheck(ctx context.Context, check ServerCheck) (CheckReport, error) {
+	defer s.checks.Done()
+
+	options := s.options.Runner
+	options.Modules = check.Modules
+	options.OnProgress = func(event ProgressEvent) {
+		s.publish(EventModule, ServerModuleEvent{Check: check.ID, ProgressEvent: event})
+	}
+
+	report, err := NewRunner(s.registry, options).Run(ctx)
+
+	check.Running = false
+
+	if err != nil {
+		check.ExitCode, check.Error = ExitConfigError, err.Error()
+	} else {
+		check.Status, check.ExitCode = report.Status(), report.ExitCode()
+	}
+
+	s.mu.Lock()
+	s.current = nil
+
+	if err == nil {
+		s.latest = &report
+	}
+
+	s.mu.Unlock()
+
+	s.publish(EventCheckCompleted, check)
+
+	return report, err
+}
+
+// latestCheck ANSWERS WITH THE JSON REPORT OF THE LATEST COMPLETED CHECK.
+func (s *Server) latestCheck(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	latest := s.latest
+	s.mu.Unlock()
+
+	if latest == nil {
+		writeJSONError(w, http.StatusNotFound, "no check has completed yet, trigger one with POST /api/checks")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, NewJSONReport(*latest))
+}
+
+// dependencies ANSWERS WITH THE DEPENDENCIES OF THE REQUESTED PACKAGE MANAGERS.
+func (s *Server) dependencies(w http.ResponseWriter, r *http.Request) {
+	managers := splitQueryList(r.URL.Query().Get("pm"))
+
+	var result DependencyResult
+
+	if s.options.Dependencies != nil {
+		result = s.options.Dependencies(managers)
+	} else {
+		result = GetAllDependencies(s.options.Runner.ProjectDir, managers...)
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// events STREAMS EVERY PUBLISHED EVENT UNTIL THE CLIENT DISCONNECTS OR THE SERVER STOPS.
+// A CLIENT THAT CONNECTS WHILE A CHECK RUNS RECEIVES ITS check-started EVENT FIRST.
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+
+	if !ok {
+		writeJSONError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	events, current := s.subscribe()
+	defer s.unsubscribe(events)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if current != nil {
+		if data, err := json.Marshal(current); err == nil {
+			_, _ = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", EventCheckStarted, data)
+		}
+	}
+
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(serverHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-events:
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.name, event.data); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
+
+// subscribe REGISTERS AN EVENT STREAM AND RETURNS THE CHECK THAT IS RUNNING, IF ANY.
+func (s *Server) subscribe() (chan serverEvent, *ServerCheck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := make(chan serverEvent, 64)
+	s.subscribers[events] = struct{}{}
+
+	if s.current == nil {
+		return events, nil
+	}
+
+	current := *s.current
+
+	return events, &current
+}
+
+// unsubscribe REMOVES AN EVENT STREAM.
+func (s *Server) unsubscribe(events chan serverEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.subscribers, events)
+}
+
+// publish SENDS AN EVENT TO EVERY EVENT STREAM.
+// A STREAM THAT DOES NOT KEEP UP MISSES EVENTS, SO A SLOW CLIENT NEVER STALLS A CHECK.
+func (s *Server) publish(name string, data any) {
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for events := range s.subscribers {
+		select {
+		case events <- serverEvent{name: name, data: payload}:
+		default:
+		}
+	}
+}
+
+// isLoopbackPeer REPORTS WHETHER A REQUEST WAS SENT FROM THIS MACHINE, UNLIKE THE Host HEADER ITS ADDRESS CANNOT BE SPOOFED.
+func isLoopbackPeer(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+
+	if err != nil {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+// isLoopbackHost REPORTS WHETHER THE Host OF A REQUEST NAMES localhost OR A LOOPBACK ADDRESS.
+func isLoopbackHost(hostPort string) bool {
+	host := hostPort
+
+	if h, _, err := net.SplitHostPort(hostPort); err == nil {
+		host = h
+	}
+
+	host = strings.Trim(host, "[]")
+
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+// isSameOrigin REPORTS WHETHER THE Origin OF A REQUEST IS THE SERVER ITSELF, AN OPAQUE null ORIGIN NEVER IS.
+func isSameOrigin(origin, host string) bool {
+	u, err := url.Parse(origin)
+
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+
+	return strings.EqualFold(u.Host, host)
+}
+
+// splitQueryList SPLITS A COMMA-SEPARATED QUERY VALUE, DROPPING EMPTY NAMES.
+func splitQueryList(value string) []string {
+	var names []string
+
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// writeJSON ANSWERS WITH A JSON DOCUMENT.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	_ = encoder.Encode(v)
+}
+
+// writeJSONError ANSWERS WITH A JSON ERROR.
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
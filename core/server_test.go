@@ -0,0 +1,263 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// blockingModule DOES NOT COMPLETE UNTIL release IS CLOSED.
+type blockingModule struct {
+	release chan struct{}
+}
+
+func (m blockingModule) Name() string {
+	return "Blocking"
+}
+
+func (m blockingModule) CheckRequirements(ctx context.Context) []Finding {
+	select {
+	case <-m.release:
+	case <-ctx.Done():
+	}
+
+	return []Finding{{Severity: SeveritySuccess, RuleID: RulePHPVersion, Message: "Released."}}
+}
+
+// newTestServer CREATES A SERVER FOR A SINGLE MODULE IN AN EMPTY PROJECT.
+func newTestServer(t *testing.T, module Module, options ServerOptions) *Server {
+	t.Helper()
+
+	registry := NewRegistry()
+
+	if err := registry.Register("", module); err != nil {
+		t.Fatal(err)
+	}
+
+	options.Runner = RunnerOptions{ProjectDir: t.TempDir()}
+
+	return NewServer(registry, options)
+}
+
+func TestServerGuard(t *testing.T) {
+	const token = "secret"
+
+	tests := []struct {
+		name    string
+		token   string
+		origins []string
+		method  string
+		remote  string
+		host    string
+		header  map[string]string
+		want    int
+		cors    bool
+	}{
+		{name: "local request", remote: "127.0.0.1:50000", host: "localhost:7878", want: http.StatusNotFound},
+		{name: "local IPv6 request", remote: "[::1]:50000", host: "[::1]:7878", want: http.StatusNotFound},
+		{name: "non-loopback host", remote: "127.0.0.1:50000", host: "attacker.example:7878", want: http.StatusForbidden},
+		{name: "spoofed host from the network", remote: "192.0.2.10:50000", host: "localhost:7878", want: http.StatusForbidden},
+		{
+			name:   "cross-origin request",
+			remote: "127.0.0.1:50000",
+			host:   "127.0.0.1:7878",
+			header: map[string]string{"Origin": "https://attacker.example"},
+			want:   http.StatusForbidden,
+		},
+		{
+			name:   "same-origin request",
+			remote: "127.0.0.1:50000",
+			host:   "127.0.0.1:7878",
+			header: map[string]string{"Origin": "http://127.0.0.1:7878"},
+			want:   http.StatusNotFound,
+		},
+		{name: "missing token", token: token, remote: "192.0.2.10:50000", host: "ci.example:7878", want: http.StatusUnauthorized},
+		{
+			name:   "wrong token",
+			token:  token,
+			remote: "192.0.2.10:50000",
+			host:   "ci.example:7878",
+			header: map[string]string{"Authorization": "Bearer wrong"},
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "valid token from the network",
+			token:  token,
+			remote: "192.0.2.10:50000",
+			host:   "ci.example:7878",
+			header: map[string]string{"Authorization": "Bearer " + token},
+			want:   http.StatusNotFound,
+		},
+		{
+			name:    "allowed origin without a token",
+			origins: []string{"https://dashboard.example"},
+			remote:  "127.0.0.1:50000",
+			host:    "127.0.0.1:7878",
+			header:  map[string]string{"Origin": "https://dashboard.example"},
+			want:    http.StatusForbidden,
+		},
+		{
+			name:    "origin that is not allowed",
+			token:   token,
+			origins: []string{"https://dashboard.example"},
+			remote:  "127.0.0.1:50000",
+			host:    "127.0.0.1:7878",
+			header:  map[string]string{"Origin": "https://attacker.example", "Authorization": "Bearer " + token},
+			want:    http.StatusForbidden,
+		},
+		{
+			name:    "allowed origin",
+			token:   token,
+			origins: []string{"https://dashboard.example"},
+			remote:  "127.0.0.1:50000",
+			host:    "127.0.0.1:7878",
+			header:  map[string]string{"Origin": "https://dashboard.example", "Authorization": "Bearer " + token},
+			want:    http.StatusNotFound,
+			cors:    true,
+		},
+		{
+			name:    "preflight of an allowed origin",
+			token:   token,
+			origins: []string{"https://dashboard.example"},
+			method:  http.MethodOptions,
+			remote:  "127.0.0.1:50000",
+			host:    "127.0.0.1:7878",
+			header:  map[string]string{"Origin": "https://dashboard.example", "Access-Control-Request-Method": "POST"},
+			want:    http.StatusNoContent,
+			cors:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t, fakeModule{name: "PHP"}, ServerOptions{Token: test.token, AllowedOrigins: test.origins})
+
+			method := test.method
+
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			// NO CHECK HAS COMPLETED YET, SO A REQUEST THAT PASSES THE GUARD IS ANSWERED WITH 404.
+			request := httptest.NewRequest(method, "/api/checks/latest", nil)
+			request.RemoteAddr = test.remote
+			request.Host = test.host
+
+			for name, value := range test.header {
+				request.Header.Set(name, value)
+			}
+
+			recorder := httptest.NewRecorder()
+			server.Handler(context.Background()).ServeHTTP(recorder, request)
+
+			if recorder.Code != test.want {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, test.want, recorder.Body)
+			}
+
+			if allowed := recorder.Header().Get("Access-Control-Allow-Origin"); (allowed != "") != test.cors {
+				t.Errorf("Access-Control-Allow-Origin = %q, want CORS headers %v", allowed, test.cors)
+			}
+		})
+	}
+}
+
+func TestServerChecks(t *testing.T) {
+	release := make(chan struct{})
+	server := newTestServer(t, blockingModule{release: release}, ServerOptions{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer := httptest.NewServer(server.Handler(ctx))
+	defer httpServer.Close()
+
+	get := func(path string) int {
+		response, err := http.Get(httpServer.URL + path)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_ = response.Body.Close()
+
+		return response.StatusCode
+	}
+
+	post := func(path string) int {
+		response, err := http.Post(httpServer.URL+path, "", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_ = response.Body.Close()
+
+		return response.StatusCode
+	}
+
+	if status := get("/api/checks/latest"); status != http.StatusNotFound {
+		t.Fatalf("latest before any check = %d, want 404", status)
+	}
+
+	stream, err := http.Get(httpServer.URL + "/api/events")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = stream.Body.Close() }()
+
+	if status := post("/api/checks"); status != http.StatusAccepted {
+		t.Fatalf("first check = %d, want 202", status)
+	}
+
+	if status := post("/api/checks"); status != http.StatusConflict {
+		t.Fatalf("second check while one runs = %d, want 409", status)
+	}
+
+	close(release)
+
+	events := make(chan string, 64)
+
+	go func() {
+		defer close(events)
+
+		scanner := bufio.NewScanner(stream.Body)
+
+		for scanner.Scan() {
+			if name, ok := strings.CutPrefix(scanner.Text(), "event: "); ok {
+				events <- name
+			}
+		}
+	}()
+
+	var received []string
+
+	for name := range events {
+		received = append(received, name)
+
+		if name == EventCheckCompleted {
+			break
+		}
+	}
+
+	want := []string{EventCheckStarted, EventModule, EventCheckCompleted}
+
+	for _, name := range want {
+		if !strings.Contains(strings.Join(received, " "), name) {
+			t.Fatalf("events = %v, want %v", received, want)
+		}
+	}
+
+	if received[0] != EventCheckStarted || received[len(received)-1] != EventCheckCompleted {
+		t.Errorf("events = %v, want them to start with %s and end with %s", received, EventCheckStarted, EventCheckCompleted)
+	}
+
+	// THE LATEST REPORT IS KEPT BEFORE check-completed IS PUBLISHED.
+	if status := get("/api/checks/latest"); status != http.StatusOK {
+		t.Errorf("latest after a check = %d, want 200", status)
+	}
+}
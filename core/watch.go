@@ -0,0 +1,434 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// WatchDebounce IS HOW LONG WATCH MODE WAITS FOR FURTHER CHANGES BEFORE RE-RUNNING THE AFFECTED MODULES,
+// SO A BRANCH SWITCH OR AN INSTALL THAT TOUCHES MANY FILES RESULTS IN A SINGLE RUN.
+const WatchDebounce = 500 * time.Millisecond
+
+// RunWatch CHECKS THE MODULES OF A REGISTRY, THEN RE-RUNS THE MODULES AFFECTED BY EVERY CHANGE OF THEIR WATCHED FILES
+// AND REDRAWS A COMPACT STATUS VIEW, UNTIL ctx IS CANCELED. IT RETURNS THE EXIT CODE OF THE LAST COMPLETED RUN.
+func RunWatch(ctx context.Context, registry *Registry, options RunnerOptions) int {
+	keys := options.Modules
+
+	if len(keys) == 0 {
+		keys = registry.Names()
+	}
+
+	selected, err := registry.Select(keys...)
+
+	if err != nil {
+		fmt.Println(utils.Red + "Failed to run checks: " + err.Error() + utils.Reset)
+		return ExitConfigError
+	}
+
+	watcher, err := utils.NewWatcher(options.ProjectDir, watchedFiles(selected))
+
+	if err != nil {
+		fmt.Println(utils.Red + "Failed to watch the project: " + err.Error() + utils.Reset)
+		return ExitConfigError
+	}
+
+	defer func() { _ = watcher.Close() }()
+
+	// THE COMPACT VIEW REPLACES THE PROGRESS OUTPUT OF THE RUNNER.
+	options.Progress = nil
+
+	// RE-RUNS ONLY CHECK THE AFFECTED MODULES, SO THEY ARE NOT RECORDED AS RUNS OF THE WHOLE PROJECT.
+	options.History = false
+	order := moduleNames(OrderModules(SortModules(selected, options.Sort), options.ModuleOrder))
+	view := newWatchView(options.ProjectDir, order)
+
+	view.Running(nil)
+
+	report, err := NewRunner(registry, options).Run(ctx)
+
+	if err != nil {
+		fmt.Println(utils.Red + "Failed to run checks: " + err.Error() + utils.Reset)
+		return ExitConfigError
+	}
+
+	view.Draw(report, nil, nil)
+
+	changes := make(chan string)
+
+	go func() {
+		defer close(changes)
+
+		for {
+			name, err := watcher.Next()
+
+			if err != nil {
+				return
+			}
+
+			select {
+			case changes <- name:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	changed := make(map[string]struct{})
+
+	var debounce <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return report.ExitCode()
+
+		case name, ok := <-changes:
+			if !ok {
+				fmt.Println(utils.Red + "Stopped watching the project." + utils.Reset)
+				return report.ExitCode()
+			}
+
+			changed[name] = struct{}{}
+			debounce = time.After(WatchDebounce)
+
+		case <-debounce:
+			debounce = nil
+			names := make([]string, 0, len(changed))
+
+			for name := range changed {
+				names = append(names, name)
+			}
+
+			slices.Sort(names)
+			clear(changed)
+
+			affected := affectedModules(registry, keys, names)
+
+			if len(affected) == 0 {
+				continue
+			}
+
+			affectedNames := make([]string, 0, len(affected))
+
+			for _, key := range affected {
+				if module, ok := registry.Lookup(key); ok {
+					affectedNames = append(affectedNames, module.Name())
+				}
+			}
+
+			view.Running(names)
+
+			runnerOptions := options
+			runnerOptions.Modules = affected
+			rerun, err := NewRunner(registry, runnerOptions).Run(ctx)
+
+			if err != nil {
+				fmt.Println(utils.Red + "Failed to run checks: " + err.Error() + utils.Reset)
+				continue
+			}
+
+			// A RUN INTERRUPTED BY CTRL+C IS INCOMPLETE, THE PREVIOUS RESULTS STAND.
+			if ctx.Err() != nil {
+				return report.ExitCode()
+			}
+
+			report = mergeWatchReport(report, rerun, affectedNames, order)
+			view.Draw(report, names, affectedNames)
+		}
+	}
+}
+
+// watchedFiles RETURNS THE FILES WATCHED BY THE GIVEN MODULES, WITHOUT DUPLICATES.
+// EVERY PROJECT MANIFEST IS WATCHED AS WELL, SINCE IT DECIDES WHICH ECOSYSTEMS ARE DETECTED.
+func watchedFiles(modules []Module) []string {
+	files := slices.Clone(ProjectManifests)
+
+	for _, module := range modules {
+		if watched, ok := module.(WatchedModule); ok {
+			files = append(files, watched.WatchedFiles()...)
+		}
+	}
+
+	slices.Sort(files)
+
+	return slices.Compact(files)
+}
+
+// affectedModules RETURNS THE REGISTRY KEYS OF THE MODULES TO RE-RUN AFTER THE GIVEN FILES CHANGED.
+// DEPENDENTS OF AN AFFECTED MODULE ARE RE-RUN SINCE THEIR BLOCKED STATE MAY CHANGE,
+// AND PREREQUISITES ARE RE-RUN SO THE AFFECTED MODULES ARE STILL BLOCKED WHEN ONE OF THEM FAILS.
+func affectedModules(registry *Registry, keys []string, changed []string) []string {
+	affected := make(map[string]bool, len(keys))
+	prerequisites := make(map[string][]string, len(keys))
+
+	for _, key := range keys {
+		module, ok := registry.Lookup(key)
+
+		if !ok {
+			continue
+		}
+
+		name := strings.ToLower(module.Name())
+		watched, ok := module.(WatchedModule)
+		affected[name] = !ok || slices.ContainsFunc(watched.WatchedFiles(), func(file string) bool {
+			return slices.Contains(changed, file)
+		})
+
+		if dependent, ok := module.(DependentModule); ok {
+			for _, prerequisite := range dependent.DependsOn() {
+				prerequisites[name] = append(prerequisites[name], strings.ToLower(prerequisite))
+			}
+		}
+	}
+
+	// SPREAD TO THE DEPENDENTS UNTIL NOTHING CHANGES.
+	for grown := true; grown; {
+		grown = false
+
+		for name, names := range prerequisites {
+			if !affected[name] && slices.ContainsFunc(names, func(prerequisite string) bool { return affected[prerequisite] }) {
+				affected[name] = true
+				grown = true
+			}
+		}
+	}
+
+	// THEN SPREAD TO THE PREREQUISITES OF EVERYTHING AFFECTED.
+	for grown := true; grown; {
+		grown = false
+
+		for name, names := range prerequisites {
+			if !affected[name] {
+				continue
+			}
+
+			for _, prerequisite := range names {
+				if _, registered := affected[prerequisite]; registered && !affected[prerequisite] {
+					affected[prerequisite] = true
+					grown = true
+				}
+			}
+		}
+	}
+
+	var selected []string
+
+	for _, key := range keys {
+		if module, ok := registry.Lookup(key); ok && affected[strings.ToLower(module.Name())] {
+			selected = append(selected, key)
+		}
+	}
+
+	return selected
+}
+
+// mergeWatchReport REPLACES THE RESULTS AND DETECTIONS OF THE RE-RUN MODULES, KEEPING THE ORDER OF ALL MODULES.
+// A RE-RUN MODULE WITHOUT A RESULT IS NO LONGER DETECTED AND DROPS ITS PREVIOUS RESULT.
+func mergeWatchReport(previous, rerun CheckReport, rerunNames, order []string) CheckReport {
+	results := make(map[string]CheckResult, len(previous.Results))
+	detections := make(map[string]Detection, len(previous.Detections))
+
+	for _, result := range previous.Results {
+		if !slices.Contains(rerunNames, result.Scope) {
+			results[result.Scope] = result
+		}
+	}
+
+	for _, detection := range previous.Detections {
+		if !slices.Contains(rerunNames, detection.Module) {
+			detections[detection.Module] = detection
+		}
+	}
+
+	for _, result := range rerun.Results {
+		results[result.Scope] = result
+	}
+
+	for _, detection := range rerun.Detections {
+		detections[detection.Module] = detection
+	}
+
+	merged := rerun
+	merged.Results = nil
+	merged.Detections = nil
+
+	for _, name := range order {
+		if result, ok := results[name]; ok {
+			merged.Results = append(merged.Results, result)
+		}
+
+		if detection, ok := detections[name]; ok {
+			merged.Detections = append(merged.Detections, detection)
+		}
+	}
+
+	return merged
+}
+
+// moduleNames RETURNS THE NAMES OF THE GIVEN MODULES IN ORDER.
+func moduleNames(modules []Module) []string {
+	names := make([]string, len(modules))
+
+	for i, module := range modules {
+		names[i] = module.Name()
+	}
+
+	return names
+}
+
+// watchView DRAWS THE COMPACT STATUS VIEW OF WATCH MODE, ON A TERMINAL THE SCREEN IS CLEARED BEFORE EVERY DRAW.
+type watchView struct {
+	ow    *utils.OutputWriter
+	live  bool
+	dir   string
+	names []string
+	width int
+}
+
+// newWatchView CREATES THE VIEW FOR THE PROJECT IN projectDir AND THE GIVEN MODULE NAMES, WRITING TO STDOUT.
+func newWatchView(projectDir string, names []string) *watchView {
+	dir, err := filepath.Abs(projectDir)
+
+	if err != nil {
+		dir = projectDir
+	}
+
+	width := 0
+
+	for _, name := range names {
+		width = max(width, len(name))
+	}
+
+	return &watchView{
+		ow:    utils.NewOutputWriterTo(os.Stdout),
+		live:  utils.IsTerminal(os.Stdout),
+		dir:   dir,
+		names: names,
+		width: width,
+	}
+}
+
+// Running SHOWS THAT A RUN STARTED, TRIGGERED BY THE GIVEN CHANGED FILES.
+func (v *watchView) Running(changed []string) {
+	if len(changed) == 0 {
+		v.ow.Printf("%sChecking %s...%s\n", utils.Dim, v.dir, utils.Reset)
+		return
+	}
+
+	v.ow.Printf("%s%s changed, checking...%s\n", utils.Dim, strings.Join(changed, ", "), utils.Reset)
+}
+
+// Draw SHOWS ONE LINE PER MODULE IN ORDER WITH ITS ERRORS AND WARNINGS, FOLLOWED BY THE OVERALL STATUS AND WHAT TRIGGERED THE RUN.
+func (v *watchView) Draw(report CheckReport, changed []string, rechecked []string) {
+	if v.live {
+		v.ow.Printf("\033[H\033[2J")
+	} else {
+		v.ow.Println("")
+	}
+
+	v.ow.Println(utils.Bold + utils.Cyan + "🚀 PreFlight watching " + v.dir + utils.Reset + utils.Dim + " (Ctrl+C to stop)" + utils.Reset)
+	v.ow.Println("")
+
+	for _, name := range v.names {
+		result, hasResult := findResult(report.Results, name)
+		detection, hasDetection := findDetection(report.Detections, name)
+
+		switch {
+		case hasResult:
+			icon, color, _ := statusSummary(result.Status())
+			v.ow.Printf("%s  %s %-*s  %s%s\n", color, icon, v.width, name, resultSummary(result), utils.Reset)
+		case hasDetection && !detection.Detected:
+			v.ow.Printf("%s  - %-*s  skipped (%s)%s\n", utils.Dim, v.width, name, detection.Reason, utils.Reset)
+		default:
+			v.ow.Printf("%s  - %-*s  not applicable%s\n", utils.Dim, v.width, name, utils.Reset)
+		}
+
+		for _, finding := range result.Findings {
+			switch finding.Severity {
+			case SeverityError:
+				v.ow.Printf("%s      %s %s%s\n", utils.Red, utils.CrossMark, finding.Message, utils.Reset)
+			case SeverityWarning:
+				v.ow.Printf("%s      %s %s%s\n", utils.Yellow, utils.WarningSign, finding.Message, utils.Reset)
+			}
+		}
+	}
+
+	icon, color, text := statusSummary(report.Status())
+	v.ow.Println("")
+	v.ow.Println(utils.Bold + color + icon + " " + text + utils.Reset)
+
+	last := utils.Dim + "Last run " + report.EndedAt.Format(endedAtLayout)
+
+	if len(changed) > 0 {
+		last += " · " + strings.Join(changed, ", ") + " changed · re-checked " + strings.Join(rechecked, ", ")
+	}
+
+	v.ow.Println(last + utils.Reset)
+}
+
+// resultSummary DESCRIBES THE OUTCOME OF A MODULE IN A FEW WORDS.
+func resultSummary(result CheckResult) string {
+	switch {
+	case result.TimedOut:
+		return "timed out"
+	case len(result.BlockedBy) > 0:
+		return "blocked by " + strings.Join(result.BlockedBy, ", ")
+	}
+
+	var parts []string
+
+	if errors := len(result.Errors()); errors > 0 {
+		parts = append(parts, plural(errors, "error"))
+	}
+
+	if warnings := len(result.Warnings()); warnings > 0 {
+		parts = append(parts, plural(warnings, "warning"))
+	}
+
+	if successes := len(result.Successes()); successes > 0 {
+		parts = append(parts, fmt.Sprintf("%d passed", successes))
+	}
+
+	if len(parts) == 0 {
+		return "nothing to check"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// plural FORMATS A COUNT WITH ITS NOUN, ADDING AN S UNLESS THE COUNT IS ONE.
+func plural(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// findResult RETURNS THE RESULT OF THE GIVEN SCOPE.
+func findResult(results []CheckResult, scope string) (CheckResult, bool) {
+	for _, result := range results {
+		if result.Scope == scope {
+			return result, true
+		}
+	}
+
+	return CheckResult{}, false
+}
+
+// findDetection RETURNS THE DETECTION OF THE GIVEN MODULE.
+func findDetection(detections []Detection, module string) (Detection, bool) {
+	for _, detection := range detections {
+		if detection.Module == module {
+			return detection, true
+		}
+	}
+
+	return Detection{}, false
+}
@@ -1,12 +1,13 @@
 package modules
 
 import (
-	"PreFlight/config"
-	"PreFlight/utils"
 	"context"
 	"encoding/json"
 	"fmt"
-	"os/exec"
+	"github.com/MineHubs-Studios/PreFlight/config"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"maps"
 	"strings"
 	"sync"
 )
@@ -17,67 +18,158 @@ func (c ComposerModule) Name() string {
 	return "Composer"
 }
 
+// DependsOn Composer CANNOT RUN WITHOUT A WORKING PHP.
+func (c ComposerModule) DependsOn() []string {
+	return []string{"php"}
+}
+
+// Detect Composer IS CHECKED FOR PROJECTS WITH A composer.json OR composer.lock.
+func (c ComposerModule) Detect(projectRoot string) (bool, string) {
+	return detectFiles(projectRoot, "composer.json", "composer.lock")
+}
+
+// WatchedFiles COMPOSER IS RE-CHECKED WHEN ITS MANIFEST, LOCK FILE OR INSTALLED PACKAGES CHANGE.
+func (c ComposerModule) WatchedFiles() []string {
+	return []string{"composer.json", "composer.lock", "vendor"}
+}
+
+// CacheInputs INSTALLED PACKAGES ARE TRACKED BY vendor/composer/installed.json.
+func (c ComposerModule) CacheInputs(_ context.Context) core.CacheInputs {
+	return core.CacheInputs{
+		Files: []string{"composer.json", "composer.lock", "vendor/composer/installed.json"},
+		Tools: []string{"composer", "php"},
+	}
+}
+
+// Requirements LISTS Composer ITSELF, composer.json AND ALL DECLARED DEPENDENCIES.
+func (c ComposerModule) Requirements(ctx context.Context) []string {
+	composerConfig := config.LoadComposerConfig(utils.ProjectDir(ctx))
+
+	requirements := []string{"composer", "composer.json"}
+	requirements = append(requirements, composerConfig.Dependencies...)
+
+	return append(requirements, composerConfig.DevDependencies...)
+}
+
 // CheckRequirements VERIFIES Composer CONFIGURATIONS AND DEPENDENCIES.
-func (c ComposerModule) CheckRequirements(ctx context.Context) (errors []string, warnings []string, successes []string) {
+func (c ComposerModule) CheckRequirements(ctx context.Context) []core.Finding {
 	// CHECK IF CONTEXT IS CANCELED.
 	if ctx.Err() != nil {
-		return nil, nil, nil
+		return nil
 	}
 
-	composerConfig := config.LoadComposerConfig()
+	composerConfig := config.LoadComposerConfig(utils.ProjectDir(ctx))
 	pm := composerConfig.PackageManager
 
 	if pm.LockFile == "" && !composerConfig.HasJSON {
-		return nil, nil, nil
+		return nil
 	}
 
+	var findings []core.Finding
+
 	if !composerConfig.HasJSON {
-		warnings = append(warnings, "composer.json not found.")
+		findings = append(findings, core.Finding{
+			Severity: core.SeverityWarning,
+			Module:   c.Name(),
+			RuleID:   core.RuleComposerManifest,
+			Subject:  "composer.json",
+			File:     utils.ProjectPath(ctx, "composer.json"),
+			Message:  "composer.json not found.",
+		})
 
 		if pm.LockFile != "" {
-			warnings = append(warnings, fmt.Sprintf("composer.json not found, but %s exists. Ensure composer.json is included in your project.", pm.LockFile))
+			findings = append(findings, core.Finding{
+				Severity: core.SeverityWarning,
+				Module:   c.Name(),
+				RuleID:   core.RuleComposerLockOnly,
+				Subject:  pm.LockFile,
+				File:     utils.ProjectPath(ctx, pm.LockFile),
+				Message:  fmt.Sprintf("composer.json not found, but %s exists. Ensure composer.json is included in your project.", pm.LockFile),
+			})
 		}
 
-		return errors, warnings, successes
+		return findings
 	}
 
 	composerVersion, err := GetComposerVersion(ctx)
 
 	if err != nil {
-		errors = append(errors, "Composer is not installed or not available in path.")
-		return errors, warnings, successes
+		return append(findings, core.Finding{
+			Severity:    core.SeverityError,
+			Module:      c.Name(),
+			RuleID:      core.RuleComposerInstalled,
+			Subject:     "composer",
+			Remediation: "Install Composer from https://getcomposer.org",
+			Message:     notInstalled(ctx, "Composer") + ".",
+		})
 	}
 
-	successes = append(successes, fmt.Sprintf("Installed %sComposer (%s).", utils.Reset, composerVersion))
-
-	if !composerConfig.HasJSON && composerConfig.HasLock {
-		warnings = append(warnings, "composer.lock exists without composer.json. Consider including composer.json.")
-		return errors, warnings, successes
-	}
+	findings = append(findings, core.Finding{
+		Severity:  core.SeveritySuccess,
+		Module:    c.Name(),
+		RuleID:    core.RuleComposerInstalled,
+		Subject:   "composer",
+		Installed: composerVersion,
+		Message:   fmt.Sprintf("Installed Composer (%s).", composerVersion),
+	})
 
 	if composerConfig.Error != nil {
-		errors = append(errors, fmt.Sprintf("Error reading composer.json: %v", composerConfig.Error))
-		return errors, warnings, successes
+		return append(findings, core.Finding{
+			Severity: core.SeverityError,
+			Module:   c.Name(),
+			RuleID:   core.RuleComposerManifest,
+			Subject:  "composer.json",
+			File:     utils.ProjectPath(ctx, "composer.json"),
+			Message:  fmt.Sprintf("Error reading composer.json: %v", composerConfig.Error),
+		})
 	}
 
-	successes = append(successes, "composer.json found.")
+	findings = append(findings, core.Finding{
+		Severity: core.SeveritySuccess,
+		Module:   c.Name(),
+		RuleID:   core.RuleComposerManifest,
+		Subject:  "composer.json",
+		File:     utils.ProjectPath(ctx, "composer.json"),
+		Message:  "composer.json found.",
+	})
+
 	installedDependencies := GetInstalledDependencies(ctx, composerConfig.Dependencies, composerConfig.DevDependencies)
 
+	if ctx.Err() != nil {
+		return findings
+	}
+
 	for _, dep := range append(composerConfig.Dependencies, composerConfig.DevDependencies...) {
+		finding := core.Finding{
+			Module:  c.Name(),
+			RuleID:  core.RuleComposerDependency,
+			Subject: dep,
+			File:    utils.ProjectPath(ctx, "composer.json"),
+		}
+
 		if version, exists := installedDependencies[dep]; exists {
-			successes = append(successes, fmt.Sprintf("Installed dependency %s%s (%s).", utils.Reset, dep, version))
+			finding.Severity = core.SeveritySuccess
+			finding.Installed = version
+			finding.Message = fmt.Sprintf("Installed dependency %s (%s).", dep, version)
 		} else {
-			errors = append(errors, fmt.Sprintf("Missing dependency %s%s, Run `composer require %s`.", utils.Reset, dep, dep))
+			finding.Severity = core.SeverityError
+			finding.Remediation = fmt.Sprintf("composer require %s", dep)
+			finding.Message = fmt.Sprintf("Missing dependency %s, Run `composer require %s`.", dep, dep)
 		}
+
+		findings = append(findings, finding)
 	}
 
-	return errors, warnings, successes
+	return findings
 }
 
 // GetComposerVersion RETRIEVES THE INSTALLED Composer VERSION.
 func GetComposerVersion(ctx context.Context) (string, error) {
-	cmd := exec.CommandContext(ctx, "composer", "--version")
-	output, err := cmd.Output()
+	if env := core.EnvironmentFrom(ctx); env != nil {
+		return env.ToolVersion("composer")
+	}
+
+	output, err := utils.RunCommand(ctx, "composer", "--version")
 
 	if err != nil {
 		return "", err
@@ -94,14 +186,17 @@ func GetComposerVersion(ctx context.Context) (string, error) {
 
 // GetInstalledDependencies RETRIEVES THE INSTALLED Composer DEPENDENCIES.
 func GetInstalledDependencies(ctx context.Context, dependencies, devDependencies []string) map[string]string {
+	if env := core.EnvironmentFrom(ctx); env != nil {
+		return maps.Clone(env.Dependencies["composer"])
+	}
+
 	installedDependencies := make(map[string]string)
 	allDeps := append(dependencies, devDependencies...)
 
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 
-	cmd := exec.CommandContext(ctx, "composer", "show", "--format=json")
-	output, err := cmd.Output()
+	output, err := utils.RunCommand(ctx, "composer", "show", "--format=json")
 
 	if err == nil {
 		var data struct {
@@ -127,8 +222,7 @@ func GetInstalledDependencies(ctx context.Context, dependencies, devDependencies
 
 		go func(dep string) {
 			defer wg.Done()
-			cmd := exec.CommandContext(ctx, "composer", "show", dep)
-			output, err := cmd.Output()
+			output, err := utils.RunCommand(ctx, "composer", "show", dep)
 
 			if err == nil {
 				for _, line := range strings.Split(string(output), "\n") {
@@ -0,0 +1,206 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/config"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"regexp"
+	"strings"
+)
+
+// CustomModule CHECKS A TOOL DECLARED UNDER customModules IN THE PROJECT CONFIGURATION.
+type CustomModule struct {
+	definition   config.CustomModuleConfig
+	versionArgs  []string
+	versionRegex *regexp.Regexp
+	file         string
+}
+
+// NewCustomModule VALIDATES A DECLARED MODULE, FILE IS THE CONFIGURATION IT WAS READ FROM.
+func NewCustomModule(definition config.CustomModuleConfig, file string) (CustomModule, error) {
+	definition.Binary = strings.TrimSpace(definition.Binary)
+	definition.Name = strings.TrimSpace(definition.Name)
+
+	if definition.Binary == "" {
+		return CustomModule{}, fmt.Errorf("custom module '%s' has no binary", definition.Name)
+	}
+
+	if definition.Name == "" {
+		definition.Name = definition.Binary
+	}
+
+	// THE VERSION COMMAND MAY REPEAT THE BINARY, E.G. "docker --version".
+	versionArgs := strings.Fields(definition.VersionCommand)
+
+	if len(versionArgs) > 0 && versionArgs[0] == definition.Binary {
+		versionArgs = versionArgs[1:]
+	}
+
+	if definition.VersionCommand == "" {
+		versionArgs = []string{"--version"}
+	}
+
+	versionRegex := toolVersionRegex
+
+	if definition.VersionRegex != "" {
+		var err error
+
+		if versionRegex, err = regexp.Compile(definition.VersionRegex); err != nil {
+			return CustomModule{}, fmt.Errorf("custom module '%s' has an invalid versionRegex: %w", definition.Name, err)
+		}
+	}
+
+	return CustomModule{
+		definition:   definition,
+		versionArgs:  versionArgs,
+		versionRegex: versionRegex,
+		file:         file,
+	}, nil
+}
+
+func (c CustomModule) Name() string {
+	return c.definition.Name
+}
+
+// Requirements LISTS THE DECLARED BINARY.
+func (c CustomModule) Requirements(_ context.Context) []string {
+	return []string{c.definition.Binary}
+}
+
+// CacheInputs THE FINDINGS ONLY DEPEND ON THE DECLARED BINARY AND ITS DEFINITION.
+func (c CustomModule) CacheInputs(_ context.Context) core.CacheInputs {
+	return core.CacheInputs{
+		Tools:    []string{c.definition.Binary},
+		Settings: []string{fmt.Sprintf("%+v", c.definition)},
+	}
+}
+
+// CheckRequirements VERIFIES THAT THE DECLARED BINARY IS INSTALLED IN A SUPPORTED VERSION.
+func (c CustomModule) CheckRequirements(ctx context.Context) []core.Finding {
+	// CHECK IF CONTEXT IS CANCELED.
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	binary, constraint := c.definition.Binary, c.definition.Constraint
+	installedVersion, err := c.installedVersion(ctx)
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	if err != nil {
+		return []core.Finding{{
+			Severity:    core.SeverityError,
+			Module:      c.Name(),
+			RuleID:      core.RuleCustomInstalled,
+			Subject:     binary,
+			Required:    constraint,
+			File:        c.file,
+			Remediation: c.remediation(fmt.Sprintf("Install %s", binary)),
+			Message:     notInstalled(ctx, binary) + ".",
+		}}
+	}
+
+	if installedVersion == "" {
+		return []core.Finding{{
+			Severity:    core.SeverityError,
+			Module:      c.Name(),
+			RuleID:      core.RuleCustomInstalled,
+			Subject:     binary,
+			Required:    constraint,
+			File:        c.file,
+			Remediation: c.remediation(fmt.Sprintf("Check the versionRegex of %s", c.Name())),
+			Message:     fmt.Sprintf("Could not determine the %s version from `%s %s`.", binary, binary, strings.Join(c.versionArgs, " ")),
+		}}
+	}
+
+	var findings []core.Finding
+
+	isEOL := false
+
+	for _, eolVersion := range c.definition.EOL {
+		if installedVersion == eolVersion || strings.HasPrefix(installedVersion, eolVersion+".") {
+			findings = append(findings, core.Finding{
+				Severity:    core.SeverityWarning,
+				Module:      c.Name(),
+				RuleID:      core.RuleCustomEOL,
+				Subject:     binary,
+				Required:    constraint,
+				Installed:   installedVersion,
+				File:        c.file,
+				Remediation: c.remediation(fmt.Sprintf("Upgrade to a supported %s version", binary)),
+				Message:     fmt.Sprintf("Installed %s (%s ⟶ End-of-Life), consider upgrading!", binary, installedVersion),
+			})
+			isEOL = true
+			break
+		}
+	}
+
+	finding := core.Finding{
+		Severity:  core.SeveritySuccess,
+		Module:    c.Name(),
+		RuleID:    core.RuleCustomVersion,
+		Subject:   binary,
+		Required:  constraint,
+		Installed: installedVersion,
+		File:      c.file,
+		Message:   fmt.Sprintf("Installed %s (%s).", binary, installedVersion),
+	}
+
+	if constraint != "" {
+		finding.Message = fmt.Sprintf("Installed %s (%s ⟶ required %s).", binary, installedVersion, constraint)
+
+		if isValid, _ := utils.ValidateVersion(installedVersion, constraint); !isValid {
+			finding.Severity = core.SeverityError
+			finding.Remediation = c.remediation(fmt.Sprintf("Install a %s version matching %s", binary, constraint))
+			return append(findings, finding)
+		}
+	}
+
+	if !isEOL {
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// parseVersion EXTRACTS THE VERSION FROM THE OUTPUT, PREFERRING THE FIRST CAPTURE GROUP OF THE REGEX.
+func (c CustomModule) parseVersion(output string) string {
+	matches := c.versionRegex.FindStringSubmatch(output)
+
+	switch {
+	case len(matches) > 1:
+		return strings.TrimSpace(matches[1])
+	case len(matches) == 1:
+		return strings.TrimSpace(matches[0])
+	default:
+		return ""
+	}
+}
+
+// remediation RETURNS THE DECLARED REMEDIATION, OR THE GIVEN DEFAULT WHEN NONE IS DECLARED.
+func (c CustomModule) remediation(fallback string) string {
+	if c.definition.Remediation != "" {
+		return c.definition.Remediation
+	}
+
+	return fallback
+}
+
+// installedVersion RETRIEVES THE VERSION OF THE DECLARED BINARY, EMPTY WHEN IT CANNOT BE PARSED FROM ITS OUTPUT.
+func (c CustomModule) installedVersion(ctx context.Context) (string, error) {
+	if env := core.EnvironmentFrom(ctx); env != nil {
+		return env.ToolVersion(c.definition.Binary)
+	}
+
+	output, err := utils.RunCommand(ctx, c.definition.Binary, c.versionArgs...)
+
+	if err != nil {
+		return "", err
+	}
+
+	return c.parseVersion(string(output)), nil
+}
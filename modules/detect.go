@@ -0,0 +1,18 @@
+package modules
+
+import (
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"path/filepath"
+	"strings"
+)
+
+// detectFiles DETECTS A PROJECT BY THE FIRST OF THE GIVEN FILES OR DIRECTORIES FOUND IN projectRoot.
+func detectFiles(projectRoot string, names ...string) (bool, string) {
+	for _, name := range names {
+		if _, err := utils.Stat(filepath.Join(projectRoot, name)); err == nil {
+			return true, name + " found"
+		}
+	}
+
+	return false, "no " + strings.Join(names, " or ") + " found"
+}
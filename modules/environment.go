@@ -0,0 +1,155 @@
+package modules
+
+import (
+	"context"
+	"github.com/MineHubs-Studios/PreFlight/config"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// environmentTools ARE THE TOOLS EVERY ENVIRONMENT EXPORT LOOKS FOR, NEXT TO THE TOOLS OF THE PROJECT CONFIGURATION.
+var environmentTools = []string{"php", "composer", "node", "npm", "pnpm", "yarn", "bun", "go"}
+
+// ExportEnvironment COLLECTS THE TOOL VERSIONS, PHP BUILD, PHP EXTENSIONS AND INSTALLED DEPENDENCIES OF THE MACHINE.
+// TOOLS THAT ARE NOT INSTALLED AND DEPENDENCIES OF PACKAGE MANAGERS THE PROJECT DOES NOT USE ARE LEFT OUT.
+func ExportEnvironment(ctx context.Context, version string, projectConfig config.ProjectConfig) (core.Environment, error) {
+	env := core.NewEnvironment(version)
+	env.OS, env.Arch = runtime.GOOS, runtime.GOARCH
+
+	var customModules []CustomModule
+
+	for _, definition := range projectConfig.CustomModules {
+		module, err := NewCustomModule(definition, projectConfig.Path)
+
+		if err != nil {
+			return env, err
+		}
+
+		customModules = append(customModules, module)
+	}
+
+	tools := slices.Clone(environmentTools)
+
+	for tool := range projectConfig.Tools {
+		if !slices.Contains(tools, strings.ToLower(tool)) {
+			tools = append(tools, tool)
+		}
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	setTool := func(tool, version string) {
+		if version != "" {
+			mu.Lock()
+			env.Tools[tool] = version
+			mu.Unlock()
+		}
+	}
+
+	for _, tool := range tools {
+		wg.Add(1)
+
+		go func(tool string) {
+			defer wg.Done()
+
+			installedVersion, _ := getToolVersion(ctx, tool)
+			setTool(tool, installedVersion)
+		}(tool)
+	}
+
+	for _, module := range customModules {
+		wg.Add(1)
+
+		go func(module CustomModule) {
+			defer wg.Done()
+
+			installedVersion, _ := module.installedVersion(ctx)
+			setTool(module.definition.Binary, installedVersion)
+		}(module)
+	}
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		if php := exportPHP(ctx); php != nil {
+			mu.Lock()
+			env.PHP = php
+			mu.Unlock()
+		}
+	}()
+
+	wg.Wait()
+
+	projectDir := utils.ProjectDir(ctx)
+
+	if composerConfig := config.LoadComposerConfig(projectDir); composerConfig.HasJSON {
+		env.Dependencies["composer"] = GetInstalledDependencies(ctx, nil, nil)
+	}
+
+	if packageConfig := config.LoadPackageConfig(projectDir); packageConfig.HasJSON {
+		if installedPackages, err := getInstalledPackages(ctx); err == nil {
+			// DECLARED PACKAGES WITHOUT A VERSION ARE NOT INSTALLED.
+			for name, installedVersion := range installedPackages {
+				if installedVersion == "unknown" {
+					delete(installedPackages, name)
+				}
+			}
+
+			env.Dependencies["package"] = installedPackages
+		}
+	}
+
+	if goConfig := config.LoadGoConfig(projectDir); goConfig.HasMod {
+		installedModules := getInstalledModules(ctx)
+
+		// THE MAIN MODULE HAS NO VERSION AND IS NOT A DEPENDENCY.
+		for path, installedVersion := range installedModules {
+			if installedVersion == "" {
+				delete(installedModules, path)
+			}
+		}
+
+		env.Dependencies["go"] = installedModules
+	}
+
+	return env, ctx.Err()
+}
+
+// notInstalled DESCRIBES A MISSING TOOL, AS MISSING FROM THE EVALUATED ENVIRONMENT WHEN ONE IS SET.
+func notInstalled(ctx context.Context, tool string) string {
+	if core.EnvironmentFrom(ctx) != nil {
+		return tool + " is not installed in the environment"
+	}
+
+	return tool + " is not installed or not available in path"
+}
+
+// exportPHP RETURNS THE PHP BUILD AND ITS SORTED EXTENSIONS, OR NIL WHEN PHP IS NOT INSTALLED.
+func exportPHP(ctx context.Context) *core.EnvironmentPHP {
+	phpVersion, buildDate, vcVersion, err := getPhpVersion(ctx)
+
+	if err != nil {
+		return nil
+	}
+
+	php := &core.EnvironmentPHP{Version: phpVersion, BuildDate: buildDate, Compiler: vcVersion, Extensions: []string{}}
+	installedExtensions, _ := getPhpExtensions(ctx)
+
+	for ext := range installedExtensions {
+		// SKIP THE SECTION HEADERS OF php -m, SUCH AS [PHP Modules].
+		if !strings.HasPrefix(ext, "[") {
+			php.Extensions = append(php.Extensions, ext)
+		}
+	}
+
+	slices.Sort(php.Extensions)
+
+	return php
+}
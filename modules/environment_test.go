@@ -0,0 +1,100 @@
+package modules
+
+import (
+	"context"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// writeProject WRITES THE FILES OF A FIXTURE PROJECT TO A TEMPORARY DIRECTORY.
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil { //nolint:gosec
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+// trapCommands REPLACES PATH WITH STUBS OF EVERY TOOL THAT FAIL THE TEST WHEN ONE OF THEM IS RUN.
+func trapCommands(t *testing.T) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("command stubs are shell scripts")
+	}
+
+	bin := t.TempDir()
+	ran := filepath.Join(t.TempDir(), "ran")
+
+	for _, tool := range environmentTools {
+		stub := "#!/bin/sh\necho \"" + tool + " $*\" >> " + ran + "\nexit 1\n"
+
+		if err := os.WriteFile(filepath.Join(bin, tool), []byte(stub), 0o755); err != nil { //nolint:gosec
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv("PATH", bin)
+
+	t.Cleanup(func() {
+		if data, err := os.ReadFile(ran); err == nil { //nolint:gosec
+			t.Errorf("commands ran while evaluating against an environment:\n%s", data)
+		}
+	})
+}
+
+func TestRunAgainstEnvironment(t *testing.T) {
+	trapCommands(t)
+
+	dir := writeProject(t, map[string]string{
+		"composer.json": `{"require":{"php":"^8.2","ext-intl":"*","ext-redis":"*","laravel/framework":"^11.0"}}`,
+		"go.mod":        "module example.com/app\n\ngo 1.24\n\nrequire example.com/lib v1.2.0\n",
+	})
+
+	env := core.NewEnvironment("1.0.0")
+	env.Tools = map[string]string{"php": "8.3.4", "composer": "2.7.2", "go": "1.21.0"}
+	env.PHP = &core.EnvironmentPHP{Version: "8.3.4", Extensions: []string{"intl", "mbstring"}}
+	env.Dependencies = map[string]map[string]string{
+		"composer": {"laravel/framework": "v11.0.0"},
+		"go":       {"example.com/lib": "v1.2.0"},
+	}
+
+	runner := core.NewRunner(NewRegistry(), core.RunnerOptions{ProjectDir: dir, Jobs: 1, Environment: &env})
+	report, err := runner.Run(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	severities := make(map[string]core.Severity)
+
+	for _, result := range report.Results {
+		for _, finding := range result.Findings {
+			severities[finding.RuleID+" "+finding.Subject] = finding.Severity
+		}
+	}
+
+	want := map[string]core.Severity{
+		core.RulePHPVersion + " php":                       core.SeveritySuccess,
+		core.RulePHPExtension + " ext-intl":                core.SeveritySuccess,
+		core.RulePHPExtension + " ext-redis":               core.SeverityError,
+		core.RuleComposerDependency + " laravel/framework": core.SeveritySuccess,
+		core.RuleGoVersion + " go":                         core.SeverityError,
+		core.RuleGoModule + " example.com/lib":             core.SeveritySuccess,
+	}
+
+	for key, severity := range want {
+		if got, ok := severities[key]; !ok || got != severity {
+			t.Errorf("finding %s = %q, want %q (all findings: %v)", key, got, severity, severities)
+		}
+	}
+}
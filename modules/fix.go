@@ -1,16 +1,15 @@
-package core
+package modules
 
 import (
-	"PreFlight/config"
-	"PreFlight/modules"
-	"PreFlight/utils"
 	"context"
 	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/config"
+	"github.com/MineHubs-Studios/PreFlight/utils"
 	"os"
 	"os/exec"
 )
 
-// FixDependencies INSTALL MISSING DEPENDENCIES FOR PHP (Composer) AND JS (NPM).
+// FixDependencies INSTALL MISSING DEPENDENCIES FOR PHP (Composer) AND JS (NPM) IN THE PROJECT DIRECTORY OF THE CONTEXT.
 func FixDependencies(ctx context.Context, force bool) {
 	ow := utils.NewOutputWriter()
 
@@ -41,7 +40,7 @@ func FixDependencies(ctx context.Context, force bool) {
 
 // fixComposerDependencies HANDLES INSTALLING MISSING Composer DEPENDENCIES.
 func fixComposerDependencies(ctx context.Context, force bool) {
-	version, err := modules.GetComposerVersion(ctx)
+	version, err := GetComposerVersion(ctx)
 
 	if err != nil {
 		fmt.Println(utils.WarningSign + " Composer not found. Skipping PHP dependency fix.")
@@ -57,6 +56,7 @@ func fixComposerDependencies(ctx context.Context, force bool) {
 	}
 
 	cmd := exec.CommandContext(ctx, "composer", args...)
+	cmd.Dir = utils.ProjectDir(ctx)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -69,14 +69,14 @@ func fixComposerDependencies(ctx context.Context, force bool) {
 
 // fixJSDependencies HANDLES INSTALLING MISSING JavaScript/TypeScript DEPENDENCIES.
 func fixJSDependencies(ctx context.Context, force bool) {
-	packageConfig := config.LoadPackageConfig()
+	packageConfig := config.LoadPackageConfig(utils.ProjectDir(ctx))
 
 	if !packageConfig.HasJSON {
 		fmt.Println(utils.WarningSign + " package.json not found. Skipping JavaScript dependency fix.")
 		return
 	}
 
-	packageManager := utils.DetectPackageManager("package")
+	packageManager := utils.DetectPackageManager(utils.ProjectDir(ctx), "package")
 
 	fmt.Printf("🛠 Detected package manager: %s. Running `%s install`...\n", packageManager.Command, packageManager.Command)
 
@@ -87,6 +87,7 @@ func fixJSDependencies(ctx context.Context, force bool) {
 	}
 
 	cmd := exec.CommandContext(ctx, packageManager.Command, args...) //nolint:gosec
+	cmd.Dir = utils.ProjectDir(ctx)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -96,3 +97,27 @@ func fixJSDependencies(ctx context.Context, force bool) {
 		fmt.Printf(utils.CheckMark+" %s dependencies fixed!\n", packageManager.Command)
 	}
 }
+
+// FixPlugins RUNS THE fix COMMAND OF EVERY PLUGIN THAT SUPPORTS IT.
+func FixPlugins(ctx context.Context, force bool, plugins []PluginModule) {
+	for _, plugin := range plugins {
+		if !plugin.Supports(PluginFix) {
+			continue
+		}
+
+		fmt.Printf("🛠 Running fix of plugin %s...\n", plugin.Name())
+
+		message, err := plugin.Fix(ctx, force)
+
+		if err != nil {
+			fmt.Printf(utils.CrossMark+" Plugin %s fix failed: %v\n", plugin.Name(), err)
+			continue
+		}
+
+		if message == "" {
+			message = "dependencies fixed!"
+		}
+
+		fmt.Printf(utils.CheckMark+" %s: %s\n", plugin.Name(), message)
+	}
+}
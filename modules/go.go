@@ -1,11 +1,12 @@
 package modules
 
 import (
-	"PreFlight/config"
-	"PreFlight/utils"
 	"context"
 	"fmt"
-	"os/exec"
+	"github.com/MineHubs-Studios/PreFlight/config"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"maps"
 	"strings"
 )
 
@@ -15,77 +16,181 @@ func (g GoModule) Name() string {
 	return "Go"
 }
 
+// Detect Go IS ONLY CHECKED FOR PROJECTS WITH A go.mod.
+func (g GoModule) Detect(projectRoot string) (bool, string) {
+	return detectFiles(projectRoot, "go.mod")
+}
+
+// WatchedFiles Go REQUIREMENTS ARE DECLARED IN go.mod AND PINNED IN go.sum.
+func (g GoModule) WatchedFiles() []string {
+	return []string{"go.mod", "go.sum"}
+}
+
+// CacheInputs Go MODULES ARE DECLARED IN go.mod, PINNED IN go.sum AND, WHEN VENDORED, LISTED IN vendor/modules.txt.
+func (g GoModule) CacheInputs(_ context.Context) core.CacheInputs {
+	return core.CacheInputs{
+		Files: []string{"go.mod", "go.sum", "vendor/modules.txt"},
+		Tools: []string{"go"},
+	}
+}
+
+// Requirements LISTS go.mod, THE Go VERSION AND ALL REQUIRED MODULES.
+func (g GoModule) Requirements(ctx context.Context) []string {
+	goConfig := config.LoadGoConfig(utils.ProjectDir(ctx))
+
+	if !goConfig.HasMod {
+		return nil
+	}
+
+	requirements := []string{"go.mod", "go"}
+
+	return append(requirements, goConfig.Modules...)
+}
+
 // CheckRequirements VERIFIES Go CONFIGURATIONS AND DEPENDENCIES.
-func (g GoModule) CheckRequirements(ctx context.Context) (errors []string, warnings []string, successes []string) {
+func (g GoModule) CheckRequirements(ctx context.Context) []core.Finding {
 	// CHECK IF CONTEXT IS CANCELED.
 	if ctx.Err() != nil {
-		return nil, nil, nil
+		return nil
 	}
 
-	goVersion, err := getGoVersion(ctx)
+	goConfig := config.LoadGoConfig(utils.ProjectDir(ctx))
 
-	// SKIP MODULE IF Go IS NOT INSTALLED.
-	if err != nil {
-		return nil, nil, nil
+	if !goConfig.HasMod {
+		return nil
 	}
 
-	goConfig := config.LoadGoConfig()
+	goVersion, err := getGoVersion(ctx)
 
-	if !goConfig.HasMod {
-		return errors, warnings, successes
+	// A PROJECT WITH A go.mod NEEDS Go.
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		return []core.Finding{{
+			Severity:    core.SeverityError,
+			Module:      g.Name(),
+			RuleID:      core.RuleGoInstalled,
+			Subject:     "go",
+			Required:    goConfig.GoVersion,
+			File:        utils.ProjectPath(ctx, "go.mod"),
+			Remediation: "Install Go from https://go.dev/dl",
+			Message:     notInstalled(ctx, "Go") + ".",
+		}}
 	}
 
+	var findings []core.Finding
+
 	if goConfig.Error != nil {
-		errors = append(errors, fmt.Sprintf("Error parsing go.mod: %v", goConfig.Error))
-		return errors, warnings, successes
+		return append(findings, core.Finding{
+			Severity: core.SeverityError,
+			Module:   g.Name(),
+			RuleID:   core.RuleGoManifest,
+			Subject:  "go.mod",
+			File:     utils.ProjectPath(ctx, "go.mod"),
+			Message:  fmt.Sprintf("Error parsing go.mod: %v", goConfig.Error),
+		})
 	}
 
-	successes = append(successes, "go.mod found.")
+	findings = append(findings, core.Finding{
+		Severity: core.SeveritySuccess,
+		Module:   g.Name(),
+		RuleID:   core.RuleGoManifest,
+		Subject:  "go.mod",
+		File:     utils.ProjectPath(ctx, "go.mod"),
+		Message:  "go.mod found.",
+	})
 
 	// VALIDATE Go VERSION.
 	if goConfig.GoVersion != "" {
 		isValid, _ := utils.ValidateVersion(goVersion, goConfig.GoVersion)
 		eolVersions := []string{"1.12", "1.13", "1.14", "1.15", "1.16", "1.17", "1.18", "1.19", "1.20", "1.21", "1.22"}
-
-		feedback := fmt.Sprintf("Installed %sGo (%s ⟶ required %s).", utils.Reset, goVersion, goConfig.GoVersion)
-		isWarning := false
+		isEOL := false
 
 		for _, eolVersion := range eolVersions {
 			if strings.HasPrefix(goVersion, eolVersion+".") {
-				warnings = append(warnings, fmt.Sprintf("Installed %sGo (%s ⟶ End-of-Life), consider upgrading!", utils.Reset, goVersion))
-				isWarning = true
+				findings = append(findings, core.Finding{
+					Severity:    core.SeverityWarning,
+					Module:      g.Name(),
+					RuleID:      core.RuleGoEOL,
+					Subject:     "go",
+					Required:    goConfig.GoVersion,
+					Installed:   goVersion,
+					File:        utils.ProjectPath(ctx, "go.mod"),
+					Remediation: "Upgrade to a supported Go version",
+					Message:     fmt.Sprintf("Installed Go (%s ⟶ End-of-Life), consider upgrading!", goVersion),
+				})
+				isEOL = true
 				break
 			}
 		}
 
+		finding := core.Finding{
+			Severity:  core.SeveritySuccess,
+			Module:    g.Name(),
+			RuleID:    core.RuleGoVersion,
+			Subject:   "go",
+			Required:  goConfig.GoVersion,
+			Installed: goVersion,
+			File:      utils.ProjectPath(ctx, "go.mod"),
+			Message:   fmt.Sprintf("Installed Go (%s ⟶ required %s).", goVersion, goConfig.GoVersion),
+		}
+
 		if !isValid {
-			errors = append(errors, fmt.Sprintf("Installed %sGo (%s ⟶ required %s).", utils.Reset, goVersion, goConfig.GoVersion))
-		} else if isWarning {
-			warnings = append(warnings, feedback)
-		} else {
-			successes = append(successes, feedback)
+			finding.Severity = core.SeverityError
+			finding.Remediation = fmt.Sprintf("Install a Go version matching %s", goConfig.GoVersion)
+			findings = append(findings, finding)
+		} else if !isEOL {
+			findings = append(findings, finding)
 		}
 	} else {
-		warnings = append(warnings, "Go version requirement not specified in go.mod.")
+		findings = append(findings, core.Finding{
+			Severity: core.SeverityWarning,
+			Module:   g.Name(),
+			RuleID:   core.RuleGoVersionUnspecified,
+			Subject:  "go",
+			File:     utils.ProjectPath(ctx, "go.mod"),
+			Message:  "Go version requirement not specified in go.mod.",
+		})
 	}
 
 	installedModules := getInstalledModules(ctx)
 
+	if ctx.Err() != nil {
+		return findings
+	}
+
 	for _, mod := range goConfig.Modules {
+		finding := core.Finding{
+			Module:  g.Name(),
+			RuleID:  core.RuleGoModule,
+			Subject: mod,
+			File:    utils.ProjectPath(ctx, "go.mod"),
+		}
+
 		if _, exists := installedModules[mod]; exists {
-			successes = append(successes, fmt.Sprintf("Installed module %s%s.", utils.Reset, mod))
+			finding.Severity = core.SeveritySuccess
+			finding.Message = fmt.Sprintf("Installed module %s.", mod)
 		} else {
-			errors = append(errors, fmt.Sprintf("Missing module %s, Run 'go get %s'.", utils.Reset, mod))
+			finding.Severity = core.SeverityError
+			finding.Remediation = fmt.Sprintf("go get %s", mod)
+			finding.Message = fmt.Sprintf("Missing module %s, Run `go get %s`.", mod, mod)
 		}
+
+		findings = append(findings, finding)
 	}
 
-	return errors, warnings, successes
+	return findings
 }
 
 // getGoVersion RETRIEVES THE INSTALLED Go VERSION.
 func getGoVersion(ctx context.Context) (string, error) {
-	cmd := exec.CommandContext(ctx, "go", "version")
-	output, err := cmd.Output()
+	if env := core.EnvironmentFrom(ctx); env != nil {
+		return env.ToolVersion("go")
+	}
+
+	output, err := utils.RunCommand(ctx, "go", "version")
 
 	if err != nil {
 		return "", err
@@ -101,24 +206,25 @@ func getGoVersion(ctx context.Context) (string, error) {
 	return "", fmt.Errorf("unexpected go version format: %s", versionOutput)
 }
 
-// getInstalledModules RETRIEVES THE INSTALLED Go MODULES.
-func getInstalledModules(ctx context.Context) map[string]struct{} {
-	modules := make(map[string]struct{})
+// getInstalledModules RETRIEVES THE INSTALLED Go MODULES AND THEIR VERSIONS, THE MAIN MODULE HAS NO VERSION.
+func getInstalledModules(ctx context.Context) map[string]string {
+	if env := core.EnvironmentFrom(ctx); env != nil {
+		return maps.Clone(env.Dependencies["go"])
+	}
+
+	modules := make(map[string]string)
 
-	cmd := exec.CommandContext(ctx, "go", "list", "-m", "all")
-	output, err := cmd.Output()
+	output, err := utils.RunCommand(ctx, "go", "list", "-m", "all")
 
 	if err != nil {
 		return modules
 	}
 
 	for _, line := range strings.Split(string(output), "\n") {
-		if trimmed := strings.TrimSpace(line); trimmed != "" {
-			fields := strings.Fields(trimmed)
-
-			if len(fields) > 0 {
-				modules[fields[0]] = struct{}{}
-			}
+		if fields := strings.Fields(line); len(fields) > 1 {
+			modules[fields[0]] = fields[1]
+		} else if len(fields) == 1 {
+			modules[fields[0]] = ""
 		}
 	}
 
@@ -1,11 +1,11 @@
 package modules
 
 import (
-	"PreFlight/config"
-	"PreFlight/utils"
 	"context"
 	"fmt"
-	"os/exec"
+	"github.com/MineHubs-Studios/PreFlight/config"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/utils"
 	"strings"
 )
 
@@ -15,59 +15,128 @@ func (n NodeModule) Name() string {
 	return "Node"
 }
 
+// Detect Node.js IS ONLY CHECKED FOR PROJECTS WITH A package.json.
+func (n NodeModule) Detect(projectRoot string) (bool, string) {
+	return detectFiles(projectRoot, "package.json")
+}
+
+// WatchedFiles THE REQUIRED Node.js VERSION IS DECLARED IN package.json.
+func (n NodeModule) WatchedFiles() []string {
+	return []string{"package.json"}
+}
+
+// CacheInputs THE Node.js CHECK ONLY DEPENDS ON package.json AND THE node BINARY.
+func (n NodeModule) CacheInputs(_ context.Context) core.CacheInputs {
+	return core.CacheInputs{
+		Files: []string{"package.json"},
+		Tools: []string{"node"},
+	}
+}
+
+// Requirements LISTS THE Node.js VERSION REQUIRED BY package.json.
+func (n NodeModule) Requirements(ctx context.Context) []string {
+	if config.LoadPackageConfig(utils.ProjectDir(ctx)).NodeVersion == "" {
+		return nil
+	}
+
+	return []string{"node"}
+}
+
 // CheckRequirements VERIFIES Node.js CONFIGURATIONS.
-func (n NodeModule) CheckRequirements(ctx context.Context) (errors []string, warnings []string, successes []string) {
+func (n NodeModule) CheckRequirements(ctx context.Context) []core.Finding {
 	// CHECK IF CONTEXT IS CANCELED.
 	if ctx.Err() != nil {
-		return nil, nil, nil
+		return nil
 	}
 
+	packageConfig := config.LoadPackageConfig(utils.ProjectDir(ctx))
 	nodeVersion, err := getNodeVersion(ctx)
 
-	// SKIP MODULE IF Node.js IS NOT INSTALLED.
+	// A PROJECT WITH A package.json NEEDS Node.js, UNLESS IT RUNS ON Bun WITHOUT DECLARING engines.node.
 	if err != nil {
-		return nil, nil, nil
+		if ctx.Err() != nil || !packageConfig.HasJSON || (packageConfig.NodeVersion == "" && packageConfig.PackageManager.Command == "bun") {
+			return nil
+		}
+
+		return []core.Finding{{
+			Severity:    core.SeverityError,
+			Module:      n.Name(),
+			RuleID:      core.RuleNodeInstalled,
+			Subject:     "node",
+			Required:    packageConfig.NodeVersion,
+			File:        utils.ProjectPath(ctx, "package.json"),
+			Remediation: "Install Node.js from https://nodejs.org",
+			Message:     notInstalled(ctx, "Node.js") + ".",
+		}}
 	}
 
-	packageConfig := config.LoadPackageConfig()
+	var findings []core.Finding
 
 	if packageConfig.Error != nil {
-		warnings = append(warnings, packageConfig.Error.Error())
-		return errors, warnings, successes
+		return append(findings, core.Finding{
+			Severity: core.SeverityWarning,
+			Module:   n.Name(),
+			RuleID:   core.RuleNodeManifest,
+			Subject:  "package.json",
+			File:     utils.ProjectPath(ctx, "package.json"),
+			Message:  packageConfig.Error.Error(),
+		})
 	}
 
 	// VALIDATE Node.js VERSION.
 	if packageConfig.NodeVersion != "" {
 		isValid, _ := utils.ValidateVersion(nodeVersion, packageConfig.NodeVersion)
 		eolVersions := []string{"10", "12", "14", "15", "16", "17", "18"}
-
-		feedback := fmt.Sprintf("Installed %sNode.js (%s ⟶ required %s).", utils.Reset, nodeVersion, packageConfig.NodeVersion)
-		isWarning := false
+		isEOL := false
 
 		for _, eolVersion := range eolVersions {
 			if strings.HasPrefix(nodeVersion, "v"+eolVersion+".") {
-				warnings = append(warnings, fmt.Sprintf("Installed %sNode.js (%s ⟶ End-of-Life), consider upgrading!", utils.Reset, nodeVersion))
-				isWarning = true
+				findings = append(findings, core.Finding{
+					Severity:    core.SeverityWarning,
+					Module:      n.Name(),
+					RuleID:      core.RuleNodeEOL,
+					Subject:     "node",
+					Required:    packageConfig.NodeVersion,
+					Installed:   nodeVersion,
+					File:        utils.ProjectPath(ctx, "package.json"),
+					Remediation: "Upgrade to a supported Node.js version",
+					Message:     fmt.Sprintf("Installed Node.js (%s ⟶ End-of-Life), consider upgrading!", nodeVersion),
+				})
+				isEOL = true
 				break
 			}
 		}
 
+		finding := core.Finding{
+			Severity:  core.SeveritySuccess,
+			Module:    n.Name(),
+			RuleID:    core.RuleNodeVersion,
+			Subject:   "node",
+			Required:  packageConfig.NodeVersion,
+			Installed: nodeVersion,
+			File:      utils.ProjectPath(ctx, "package.json"),
+			Message:   fmt.Sprintf("Installed Node.js (%s ⟶ required %s).", nodeVersion, packageConfig.NodeVersion),
+		}
+
 		if !isValid {
-			errors = append(errors, fmt.Sprintf("Installed %sNode.js (%s ⟶ required %s).", utils.Reset, nodeVersion, packageConfig.NodeVersion))
-		} else if isWarning {
-			warnings = append(warnings, feedback)
-		} else {
-			successes = append(successes, feedback)
+			finding.Severity = core.SeverityError
+			finding.Remediation = fmt.Sprintf("Install a Node.js version matching %s", packageConfig.NodeVersion)
+			findings = append(findings, finding)
+		} else if !isEOL {
+			findings = append(findings, finding)
 		}
 	}
 
-	return errors, warnings, successes
+	return findings
 }
 
 // getNodeVersion RETRIEVES THE INSTALLED Node.js VERSION.
 func getNodeVersion(ctx context.Context) (string, error) {
-	cmd := exec.CommandContext(ctx, "node", "--version")
-	output, err := cmd.Output()
+	if env := core.EnvironmentFrom(ctx); env != nil {
+		return env.ToolVersion("node")
+	}
+
+	output, err := utils.RunCommand(ctx, "node", "--version")
 
 	if err != nil {
 		return "", fmt.Errorf("failed to run node --version: %w", err)
@@ -1,13 +1,14 @@
 package modules
 
 import (
-	"PreFlight/config"
-	"PreFlight/utils"
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/config"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"maps"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -19,30 +20,109 @@ func (p PackageModule) Name() string {
 	return "Package"
 }
 
+// DependsOn PACKAGES ARE ONLY CHECKED ONCE Node.js PASSES.
+func (p PackageModule) DependsOn() []string {
+	return []string{"node"}
+}
+
+// Detect PACKAGES ARE CHECKED FOR PROJECTS WITH A package.json OR node_modules.
+func (p PackageModule) Detect(projectRoot string) (bool, string) {
+	return detectFiles(projectRoot, "package.json", "node_modules")
+}
+
+// WatchedFiles PACKAGES ARE RE-CHECKED WHEN package.json, ANY LOCK FILE OR THE INSTALLED PACKAGES CHANGE.
+func (p PackageModule) WatchedFiles() []string {
+	return append([]string{"package.json", "node_modules"}, utils.LockFiles("package")...)
+}
+
+// CacheInputs INSTALLED PACKAGES ARE TRACKED BY node_modules AND THE STATE FILES PACKAGE MANAGERS WRITE INTO IT.
+func (p PackageModule) CacheInputs(_ context.Context) core.CacheInputs {
+	files := append([]string{"package.json"}, utils.LockFiles("package")...)
+	files = append(files,
+		"node_modules",
+		"node_modules/.package-lock.json",
+		"node_modules/.modules.yaml",
+		"node_modules/.yarn-state.yml",
+		"node_modules/.yarn-integrity",
+	)
+
+	return core.CacheInputs{
+		Files: files,
+		Tools: []string{"node", "npm", "pnpm", "yarn", "bun"},
+	}
+}
+
+// Requirements LISTS THE ENGINES, package.json AND ALL DECLARED PACKAGES.
+func (p PackageModule) Requirements(ctx context.Context) []string {
+	packageConfig := config.LoadPackageConfig(utils.ProjectDir(ctx))
+	requirements := make([]string, 0, len(packageConfig.Dependencies)+len(packageConfig.DevDependencies)+3)
+
+	if packageConfig.NodeVersion != "" {
+		requirements = append(requirements, "node")
+	}
+
+	engines := map[string]string{
+		"npm":  packageConfig.NPMVersion,
+		"pnpm": packageConfig.PNPMVersion,
+		"yarn": packageConfig.YarnVersion,
+	}
+
+	if engines[packageConfig.PackageManager.Command] != "" {
+		requirements = append(requirements, packageConfig.PackageManager.Command)
+	}
+
+	requirements = append(requirements, "package.json")
+	requirements = append(requirements, packageConfig.Dependencies...)
+
+	return append(requirements, packageConfig.DevDependencies...)
+}
+
 // CheckRequirements VERIFIES Package CONFIGURATIONS AND DEPENDENCIES.
-func (p PackageModule) CheckRequirements(ctx context.Context) (errors []string, warnings []string, successes []string) {
+func (p PackageModule) CheckRequirements(ctx context.Context) []core.Finding {
 	// CHECK IF CONTEXT IS CANCELED.
 	if ctx.Err() != nil {
-		return nil, nil, nil
+		return nil
 	}
 
-	packageConfig := config.LoadPackageConfig()
+	packageConfig := config.LoadPackageConfig(utils.ProjectDir(ctx))
 	pm := packageConfig.PackageManager
 
+	var findings []core.Finding
+
 	if !packageConfig.HasJSON {
-		if fi, errModules := os.Stat("node_modules"); os.IsNotExist(errModules) || !fi.IsDir() {
-			return nil, nil, nil
+		if fi, errModules := utils.Stat(utils.ProjectPath(ctx, "node_modules")); os.IsNotExist(errModules) || !fi.IsDir() {
+			return nil
 		}
 
-		errors = append(errors, "package.json not found.")
+		findings = append(findings, core.Finding{
+			Severity: core.SeverityError,
+			Module:   p.Name(),
+			RuleID:   core.RulePackageManifest,
+			Subject:  "package.json",
+			File:     utils.ProjectPath(ctx, "package.json"),
+			Message:  "package.json not found.",
+		})
 
 		if pm.LockFile != "" {
-			warnings = append(warnings, fmt.Sprintf("package.json not found, but %s exists. Ensure package.json is included in your project.", pm.LockFile))
+			findings = append(findings, core.Finding{
+				Severity: core.SeverityWarning,
+				Module:   p.Name(),
+				RuleID:   core.RulePackageLockOnly,
+				Subject:  pm.LockFile,
+				File:     utils.ProjectPath(ctx, pm.LockFile),
+				Message:  fmt.Sprintf("package.json not found, but %s exists. Ensure package.json is included in your project.", pm.LockFile),
+			})
 		} else {
-			warnings = append(warnings, "Neither package.json nor lock files (package-lock.json, bun.lock, pnpm-lock.yaml or yarn.lock) were found.")
+			findings = append(findings, core.Finding{
+				Severity: core.SeverityWarning,
+				Module:   p.Name(),
+				RuleID:   core.RulePackageManifest,
+				Subject:  "package.json",
+				Message:  "Neither package.json nor lock files (package-lock.json, bun.lock, pnpm-lock.yaml or yarn.lock) were found.",
+			})
 		}
 
-		return errors, warnings, successes
+		return findings
 	}
 
 	// HANDLE ENGINES IN package.json.
@@ -53,56 +133,130 @@ func (p PackageModule) CheckRequirements(ctx context.Context) (errors []string,
 		"yarn": packageConfig.YarnVersion,
 	}
 
+	// ONLY ONE ENGINE SUCCESS IS REPORTED, PRIORITIZING Bun FIRST, Yarn SECOND, PNPM THIRD, NPM FOURTH AND Node.js LAST.
+	enginePriority := map[string]int{"bun": 1, "yarn": 2, "pnpm": 3, "npm": 4, "node": 5}
+
+	var engineSuccess *core.Finding
+
 	for cmd, requiredVersion := range engines {
 		if requiredVersion == "" || (cmd != "node" && cmd != pm.Command) {
 			continue
 		}
 
-		out, err := exec.CommandContext(ctx, cmd, "--version").Output() //nolint:gosec
+		finding := core.Finding{
+			Module:   p.Name(),
+			RuleID:   core.RulePackageEngine,
+			Subject:  cmd,
+			Required: requiredVersion,
+			File:     utils.ProjectPath(ctx, "package.json"),
+		}
+
+		installedVersion, err := getEngineVersion(ctx, cmd)
+
+		if ctx.Err() != nil {
+			return findings
+		}
 
 		if err != nil {
-			warnings = append(warnings, fmt.Sprintf("Could not retrieve version for '%s': %v", cmd, err))
+			finding.Severity = core.SeverityWarning
+			finding.Message = fmt.Sprintf("Could not retrieve version for '%s': %v", cmd, err)
+			findings = append(findings, finding)
 			continue
 		}
 
-		installedVersion := strings.TrimSpace(string(out))
+		finding.Installed = installedVersion
 
 		if valid, _ := utils.ValidateVersion(installedVersion, requiredVersion); !valid {
-			warnings = append(warnings, fmt.Sprintf("Missing %s%s (%s ⟶ required %s).", utils.Reset, cmd, installedVersion, requiredVersion))
-		} else {
-			// ENSURE ONLY ONE SUCCESS MESSAGE, PRIORITIZING Bun FIRST, Yarn SECOND, PNPM THIRD AND NPM LAST.
-			if len(successes) == 0 || cmd == "bun" ||
-				(cmd == "yarn" && !strings.Contains(successes[0], "bun")) ||
-				(cmd == "pnpm" && !strings.Contains(successes[0], "bun") && !strings.Contains(successes[0], "yarn")) ||
-				(cmd == "npm" && !strings.Contains(successes[0], "bun") && !strings.Contains(successes[0], "yarn") && !strings.Contains(successes[0], "pnpm")) {
-				successes = []string{fmt.Sprintf("Installed %s%s (%s ⟶ required %s).", utils.Reset, cmd, installedVersion, requiredVersion)}
-			}
+			finding.Severity = core.SeverityWarning
+			finding.Remediation = fmt.Sprintf("Install a %s version matching %s", cmd, requiredVersion)
+			finding.Message = fmt.Sprintf("Missing %s (%s ⟶ required %s).", cmd, installedVersion, requiredVersion)
+			findings = append(findings, finding)
+			continue
 		}
+
+		if engineSuccess == nil || enginePriority[cmd] <= enginePriority[engineSuccess.Subject] {
+			finding.Severity = core.SeveritySuccess
+			finding.Message = fmt.Sprintf("Installed %s (%s ⟶ required %s).", cmd, installedVersion, requiredVersion)
+			engineSuccess = &finding
+		}
+	}
+
+	if engineSuccess != nil {
+		findings = append(findings, *engineSuccess)
 	}
 
-	successes = append(successes, "package.json found.")
-	installedPackages, err := getInstalledPackages()
+	findings = append(findings, core.Finding{
+		Severity: core.SeveritySuccess,
+		Module:   p.Name(),
+		RuleID:   core.RulePackageManifest,
+		Subject:  "package.json",
+		File:     utils.ProjectPath(ctx, "package.json"),
+		Message:  "package.json found.",
+	})
+
+	installedPackages, err := getInstalledPackages(ctx)
 
 	if err != nil {
-		warnings = append(warnings, fmt.Sprintf("Error getting installed packages: %v", err))
+		findings = append(findings, core.Finding{
+			Severity: core.SeverityWarning,
+			Module:   p.Name(),
+			RuleID:   core.RulePackageManifest,
+			Subject:  "package.json",
+			File:     utils.ProjectPath(ctx, "package.json"),
+			Message:  fmt.Sprintf("Error getting installed packages: %v", err),
+		})
 	}
 
 	for _, dep := range append(packageConfig.Dependencies, packageConfig.DevDependencies...) {
+		finding := core.Finding{
+			Module:  p.Name(),
+			RuleID:  core.RulePackageDependency,
+			Subject: dep,
+			File:    utils.ProjectPath(ctx, "package.json"),
+		}
+
 		if version, installed := installedPackages[dep]; installed {
-			successes = append(successes, fmt.Sprintf("Installed package %s%s (%s).", utils.Reset, dep, version))
+			finding.Severity = core.SeveritySuccess
+			finding.Installed = version
+			finding.Message = fmt.Sprintf("Installed package %s (%s).", dep, version)
 		} else {
-			errors = append(errors, fmt.Sprintf("Missing package %s%s, Run `%s install %s`.", utils.Reset, dep, pm.Command, dep))
+			finding.Severity = core.SeverityError
+			finding.Remediation = fmt.Sprintf("%s install %s", pm.Command, dep)
+			finding.Message = fmt.Sprintf("Missing package %s, Run `%s install %s`.", dep, pm.Command, dep)
 		}
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// getEngineVersion RETRIEVES THE INSTALLED VERSION OF AN ENGINE, SUCH AS node OR THE PACKAGE MANAGER.
+func getEngineVersion(ctx context.Context, cmd string) (string, error) {
+	if env := core.EnvironmentFrom(ctx); env != nil {
+		return env.ToolVersion(cmd)
 	}
 
-	return errors, warnings, successes
+	output, err := utils.RunCommand(ctx, cmd, "--version")
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
 }
 
-// getInstalledPackages RETRIEVES THE INSTALLED Package DEPENDENCIES.
-func getInstalledPackages() (map[string]string, error) {
+// getInstalledPackages RETRIEVES THE INSTALLED Package DEPENDENCIES OF THE PROJECT.
+func getInstalledPackages(ctx context.Context) (map[string]string, error) {
+	if env := core.EnvironmentFrom(ctx); env != nil {
+		return maps.Clone(env.Dependencies["package"]), nil
+	}
+
+	projectDir := utils.ProjectDir(ctx)
 	installedPackages := make(map[string]string)
+	nodeModules := filepath.Join(projectDir, "node_modules")
 
-	packageConfig := config.LoadPackageConfig()
+	packageConfig := config.LoadPackageConfig(projectDir)
 
 	if packageConfig.Error != nil {
 		return nil, packageConfig.Error
@@ -134,14 +288,14 @@ func getInstalledPackages() (map[string]string, error) {
 				return "", false
 			}
 
-			path = filepath.Join("node_modules", parts[0], parts[1], "package.json")
+			path = filepath.Join(nodeModules, parts[0], parts[1], "package.json")
 		} else {
-			path = filepath.Join("node_modules", name, "package.json")
+			path = filepath.Join(nodeModules, name, "package.json")
 		}
 
 		path = filepath.Clean(path)
 
-		if !strings.HasPrefix(path, filepath.Join("node_modules", "")) {
+		if !strings.HasPrefix(path, nodeModules) {
 			return "", false
 		}
 
@@ -160,7 +314,7 @@ func getInstalledPackages() (map[string]string, error) {
 				return
 			}
 
-			data, err := os.ReadFile(path) //nolint:gosec
+			data, err := utils.ReadFile(path) //nolint:gosec
 
 			if err == nil {
 				var packageInfo struct {
@@ -180,14 +334,14 @@ func getInstalledPackages() (map[string]string, error) {
 
 	// FALLBACK: SCAN node_modules IF NO INSTALLED PACKAGES FOUND.
 	if len(installedPackages) == 0 {
-		if entries, err := os.ReadDir("node_modules"); err == nil {
+		if entries, err := utils.ReadDir(nodeModules); err == nil {
 			for _, entry := range entries {
 				if entry.IsDir() {
 					name := entry.Name()
 
 					// HANDLE SCOPED PACKAGES (@org/package).
 					if strings.HasPrefix(name, "@") {
-						if scopedEntries, err := os.ReadDir(filepath.Join("node_modules", name)); err == nil {
+						if scopedEntries, err := utils.ReadDir(filepath.Join(nodeModules, name)); err == nil {
 							for _, scopedEntry := range scopedEntries {
 								if scopedEntry.IsDir() {
 									scopedName := name + "/" + scopedEntry.Name()
@@ -197,14 +351,14 @@ func getInstalledPackages() (map[string]string, error) {
 										continue
 									}
 
-									packagePath := filepath.Join("node_modules", name, scopedEntry.Name(), "package.json")
+									packagePath := filepath.Join(nodeModules, name, scopedEntry.Name(), "package.json")
 									packagePath = filepath.Clean(packagePath)
 
-									if !strings.HasPrefix(packagePath, filepath.Join("node_modules", "")) {
+									if !strings.HasPrefix(packagePath, nodeModules) {
 										continue
 									}
 
-									data, err := os.ReadFile(packagePath)
+									data, err := utils.ReadFile(packagePath)
 
 									if err == nil {
 										var packageInfo struct {
@@ -226,14 +380,14 @@ func getInstalledPackages() (map[string]string, error) {
 							continue
 						}
 
-						packagePath := filepath.Join("node_modules", name, "package.json")
+						packagePath := filepath.Join(nodeModules, name, "package.json")
 						packagePath = filepath.Clean(packagePath)
 
-						if !strings.HasPrefix(packagePath, filepath.Join("node_modules", "")) {
+						if !strings.HasPrefix(packagePath, nodeModules) {
 							continue
 						}
 
-						data, err := os.ReadFile(packagePath)
+						data, err := utils.ReadFile(packagePath)
 
 						if err == nil {
 							var packageInfo struct {
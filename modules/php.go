@@ -1,11 +1,11 @@
 package modules
 
 import (
-	"PreFlight/config"
-	"PreFlight/utils"
 	"context"
 	"fmt"
-	"os/exec"
+	"github.com/MineHubs-Studios/PreFlight/config"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/utils"
 	"regexp"
 	"strconv"
 	"strings"
@@ -17,25 +17,94 @@ func (p PhpModule) Name() string {
 	return "PHP"
 }
 
+// Detect PHP IS ONLY CHECKED FOR PROJECTS WITH A composer.json.
+func (p PhpModule) Detect(projectRoot string) (bool, string) {
+	return detectFiles(projectRoot, "composer.json")
+}
+
+// WatchedFiles PHP REQUIREMENTS ARE DECLARED IN composer.json.
+func (p PhpModule) WatchedFiles() []string {
+	return []string{"composer.json"}
+}
+
+// CacheInputs THE PHP VERSION AND EXTENSIONS DEPEND ON THE php BINARY, ITS INI LOCATION AND THE INI FILES IT LOADS,
+// SO ENABLING AN EXTENSION IN php.ini OR conf.d INVALIDATES THE CACHED FINDINGS.
+func (p PhpModule) CacheInputs(ctx context.Context) core.CacheInputs {
+	ini := core.CacheInputs{
+		Tools:    []string{"php"},
+		Settings: []string{utils.Getenv("PHPRC"), utils.Getenv("PHP_INI_SCAN_DIR")},
+	}
+
+	inputs := core.CacheInputs{Files: []string{"composer.json"}, Tools: ini.Tools, Settings: ini.Settings}
+
+	// WITHOUT A composer.json THERE IS NOTHING TO CHECK, SO THE INI FILES DO NOT MATTER.
+	if _, err := utils.Stat(utils.ProjectPath(ctx, "composer.json")); err != nil {
+		return inputs
+	}
+
+	// php --ini IS ONLY RUN AGAIN WHEN THE BINARY, ITS INI LOCATION OR ONE OF THE LISTED FILES CHANGED.
+	inputs.Files = append(inputs.Files, core.CachedFiles(ctx, "php-ini", ini, func() []string {
+		return getPhpIniFiles(ctx)
+	})...)
+
+	return inputs
+}
+
+// Requirements LISTS THE PHP VERSION AND EXTENSIONS REQUIRED BY composer.json.
+func (p PhpModule) Requirements(ctx context.Context) []string {
+	composerConfig := config.LoadComposerConfig(utils.ProjectDir(ctx))
+	requirements := make([]string, 0, len(composerConfig.PHPExtensions)+1)
+
+	if composerConfig.PHPVersion != "" {
+		requirements = append(requirements, "php")
+	}
+
+	for _, ext := range composerConfig.PHPExtensions {
+		requirements = append(requirements, "ext-"+ext)
+	}
+
+	return requirements
+}
+
 // CheckRequirements VERIFIES PHP CONFIGURATIONS AND EXTENSIONS.
-func (p PhpModule) CheckRequirements(ctx context.Context) (errors []string, warnings []string, successes []string) {
+func (p PhpModule) CheckRequirements(ctx context.Context) []core.Finding {
 	// CHECK IF CONTEXT IS CANCELED.
 	if ctx.Err() != nil {
-		return nil, nil, nil
+		return nil
 	}
 
+	composerConfig := config.LoadComposerConfig(utils.ProjectDir(ctx))
 	phpVersion, buildDate, vcVersion, err := getPhpVersion(ctx)
 
-	// SKIP MODULE IF PHP IS NOT INSTALLED.
+	// A PROJECT WITH A composer.json NEEDS PHP, WITHOUT ONE THERE IS NOTHING TO CHECK.
 	if err != nil {
-		return nil, nil, nil
+		if ctx.Err() != nil || !composerConfig.HasJSON {
+			return nil
+		}
+
+		return []core.Finding{{
+			Severity:    core.SeverityError,
+			Module:      p.Name(),
+			RuleID:      core.RulePHPInstalled,
+			Subject:     "php",
+			Required:    composerConfig.PHPVersion,
+			File:        utils.ProjectPath(ctx, "composer.json"),
+			Remediation: "Install PHP from https://www.php.net/downloads",
+			Message:     notInstalled(ctx, "PHP") + ".",
+		}}
 	}
 
-	composerConfig := config.LoadComposerConfig()
+	var findings []core.Finding
 
 	if composerConfig.Error != nil {
-		errors = append(errors, fmt.Sprintf("Failed to read composer.json: %v", composerConfig.Error))
-		return errors, warnings, successes
+		return append(findings, core.Finding{
+			Severity: core.SeverityError,
+			Module:   p.Name(),
+			RuleID:   core.RuleComposerManifest,
+			Subject:  "composer.json",
+			File:     utils.ProjectPath(ctx, "composer.json"),
+			Message:  fmt.Sprintf("Failed to read composer.json: %v", composerConfig.Error),
+		})
 	}
 
 	// VALIDATE PHP VERSION.
@@ -43,33 +112,51 @@ func (p PhpModule) CheckRequirements(ctx context.Context) (errors []string, warn
 		isValid, _ := utils.ValidateVersion(phpVersion, composerConfig.PHPVersion)
 		eolVersions := []string{"7.4", "8.0"}
 
-		feedback := fmt.Sprintf("Installed %sPHP (%s ⟶ required %s), Built: (%s, %s).", utils.Reset, phpVersion, composerConfig.PHPVersion, buildDate, vcVersion)
-		isWarning := false
-
-		for _, eolVersion := range eolVersions {
-			if strings.HasPrefix(phpVersion, eolVersion+".") {
-				feedback = fmt.Sprintf("Installed %sPHP (%s ⟶ End-of-Life), Consider upgrading!", utils.Reset, phpVersion)
-				isWarning = true
-				break
-			}
+		finding := core.Finding{
+			Severity:  core.SeveritySuccess,
+			Module:    p.Name(),
+			RuleID:    core.RulePHPVersion,
+			Subject:   "php",
+			Required:  composerConfig.PHPVersion,
+			Installed: phpVersion,
+			File:      utils.ProjectPath(ctx, "composer.json"),
+			Message:   fmt.Sprintf("Installed PHP (%s ⟶ required %s), Built: (%s, %s).", phpVersion, composerConfig.PHPVersion, buildDate, vcVersion),
 		}
 
 		if !isValid {
-			errors = append(errors, fmt.Sprintf("Installed %sPHP (%s ⟶ required %s), Built: (%s, %s).", utils.Reset, phpVersion, composerConfig.PHPVersion, buildDate, vcVersion))
-		} else if isWarning {
-			warnings = append(warnings, feedback)
+			finding.Severity = core.SeverityError
+			finding.Remediation = fmt.Sprintf("Install a PHP version matching %s", composerConfig.PHPVersion)
 		} else {
-			successes = append(successes, feedback)
+			for _, eolVersion := range eolVersions {
+				if strings.HasPrefix(phpVersion, eolVersion+".") {
+					finding.Severity = core.SeverityWarning
+					finding.RuleID = core.RulePHPEOL
+					finding.Remediation = "Upgrade to a supported PHP version"
+					finding.Message = fmt.Sprintf("Installed PHP (%s ⟶ End-of-Life), Consider upgrading!", phpVersion)
+					break
+				}
+			}
 		}
+
+		findings = append(findings, finding)
 	}
 
 	// CHECK PHP EXTENSIONS.
 	if len(composerConfig.PHPExtensions) > 0 {
 		installedExtensions, err := getPhpExtensions(ctx)
 
+		if ctx.Err() != nil {
+			return findings
+		}
+
 		if err != nil {
-			errors = append(errors, fmt.Sprintf("Failed to check PHP extensions: %v", err))
-			return errors, warnings, successes
+			return append(findings, core.Finding{
+				Severity: core.SeverityError,
+				Module:   p.Name(),
+				RuleID:   core.RulePHPExtensionsUnreadable,
+				Subject:  "php",
+				Message:  fmt.Sprintf("Failed to check PHP extensions: %v", err),
+			})
 		}
 
 		deprecatedExtensions := map[string]struct{}{
@@ -81,24 +168,29 @@ func (p PhpModule) CheckRequirements(ctx context.Context) (errors []string, warn
 		}
 
 		for _, ext := range composerConfig.PHPExtensions {
+			finding := core.Finding{
+				Severity: core.SeveritySuccess,
+				Module:   p.Name(),
+				RuleID:   core.RulePHPExtension,
+				Subject:  "ext-" + ext,
+				File:     utils.ProjectPath(ctx, "composer.json"),
+			}
+
 			if _, exists := installedExtensions[ext]; exists {
-				feedback := fmt.Sprintf("Installed extension %s%s.", utils.Reset, ext)
-				isWarning := false
+				finding.Installed = ext
+				finding.Message = fmt.Sprintf("Installed extension %s.", ext)
 
 				if _, deprecated := deprecatedExtensions[ext]; deprecated {
-					feedback = fmt.Sprintf("Installed extension %s(%s ⟶ deprecated), Consider removing or replacing it.", utils.Reset, ext)
-					isWarning = true
+					finding.Severity = core.SeverityWarning
+					finding.RuleID = core.RulePHPExtensionDeprecated
+					finding.Message = fmt.Sprintf("Installed extension (%s ⟶ deprecated), Consider removing or replacing it.", ext)
 				} else if _, experimental := experimentalExtensions[ext]; experimental {
-					feedback = fmt.Sprintf("Installed extension %s(%s ⟶ experimental), Use with caution.", utils.Reset, ext)
-					isWarning = true
-				}
-
-				if isWarning {
-					warnings = append(warnings, feedback)
-				} else {
-					successes = append(successes, feedback)
+					finding.Severity = core.SeverityWarning
+					finding.RuleID = core.RulePHPExtensionExperimental
+					finding.Message = fmt.Sprintf("Installed extension (%s ⟶ experimental), Use with caution.", ext)
 				}
 
+				findings = append(findings, finding)
 				continue
 			}
 
@@ -111,26 +203,38 @@ func (p PhpModule) CheckRequirements(ctx context.Context) (errors []string, warn
 				if alternatives, isSplitExt := pdoExtensions[ext]; isSplitExt {
 					for _, altExt := range alternatives {
 						if _, exists := installedExtensions[altExt]; exists {
-							successes = append(successes, fmt.Sprintf("Installed extension %s%s (%s).", utils.Reset, ext, altExt))
+							finding.Installed = altExt
+							finding.Message = fmt.Sprintf("Installed extension %s (%s).", ext, altExt)
+							findings = append(findings, finding)
 							goto NextExtension
 						}
 					}
 				}
 			}
 
-			errors = append(errors, fmt.Sprintf("Missing extension %s%s, Please enable it.", utils.Reset, ext))
+			finding.Severity = core.SeverityError
+			finding.Remediation = fmt.Sprintf("Enable the %s extension in php.ini", ext)
+			finding.Message = fmt.Sprintf("Missing extension %s, Please enable it.", ext)
+			findings = append(findings, finding)
 
 		NextExtension:
 		}
 	}
 
-	return errors, warnings, successes
+	return findings
 }
 
 // getPhpVersion RETRIEVES THE INSTALLED PHP VERSION.
 func getPhpVersion(ctx context.Context) (phpVersion, buildDate, vcVersion string, err error) {
-	cmd := exec.CommandContext(ctx, "php", "--version")
-	output, err := cmd.Output()
+	if env := core.EnvironmentFrom(ctx); env != nil {
+		if env.PHP == nil {
+			return "", "", "", fmt.Errorf("php is not installed in the environment")
+		}
+
+		return env.PHP.Version, env.PHP.BuildDate, env.PHP.Compiler, nil
+	}
+
+	output, err := utils.RunCommand(ctx, "php", "--version")
 
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to run php --version: %w", err)
@@ -163,8 +267,21 @@ func getPhpVersion(ctx context.Context) (phpVersion, buildDate, vcVersion string
 
 // getPhpExtensions RETRIEVES THE INSTALLED PHP EXTENSIONS.
 func getPhpExtensions(ctx context.Context) (map[string]struct{}, error) {
-	cmd := exec.CommandContext(ctx, "php", "-m")
-	output, err := cmd.Output()
+	if env := core.EnvironmentFrom(ctx); env != nil {
+		if env.PHP == nil {
+			return nil, fmt.Errorf("php is not installed in the environment")
+		}
+
+		extensions := make(map[string]struct{}, len(env.PHP.Extensions))
+
+		for _, ext := range env.PHP.Extensions {
+			extensions[ext] = struct{}{}
+		}
+
+		return extensions, nil
+	}
+
+	output, err := utils.RunCommand(ctx, "php", "-m")
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to run php -m: %w", err)
@@ -181,6 +298,52 @@ func getPhpExtensions(ctx context.Context) (map[string]struct{}, error) {
 	return extensions, nil
 }
 
+// getPhpIniFiles RETURNS THE INI FILES PHP LOADS AND THE DIRECTORY IT SCANS FOR ADDITIONAL ONES, AS REPORTED BY php --ini.
+func getPhpIniFiles(ctx context.Context) []string {
+	output, err := utils.RunCommand(ctx, "php", "--ini")
+
+	if err != nil {
+		return nil
+	}
+
+	var files []string
+	additional := false
+
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+
+		// EVERY LABEL ENDS WITH A COLON, PATHS ON WINDOWS CONTAIN ONE TOO, SO THE LABELS ARE MATCHED AS PREFIXES.
+		switch {
+		case strings.HasPrefix(line, "Loaded Configuration File:"), strings.HasPrefix(line, "Scan for additional .ini files in:"):
+			additional = false
+
+			if value := strings.TrimSpace(line[strings.Index(line, ":")+1:]); value != "" && value != "(none)" {
+				files = append(files, value)
+			}
+
+			continue
+		case strings.HasPrefix(line, "Additional .ini files parsed:"):
+			additional = true
+			line = strings.TrimPrefix(line, "Additional .ini files parsed:")
+		case strings.HasPrefix(line, "Configuration File (php.ini) Path:"):
+			additional = false
+		}
+
+		// ADDITIONAL INI FILES ARE LISTED COMMA SEPARATED, CONTINUED ON THE FOLLOWING LINES.
+		if !additional {
+			continue
+		}
+
+		for _, file := range strings.Split(line, ",") {
+			if file = strings.TrimSpace(file); file != "" && file != "(none)" {
+				files = append(files, file)
+			}
+		}
+	}
+
+	return files
+}
+
 // checkPHP84OrHigher DETERMINES IF THE PHP VERSION IS 8.4 OR HIGHER.
 func checkPHP84OrHigher(phpVersion string) bool {
 	parts := strings.Split(phpVersion, ".")
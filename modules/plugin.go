@@ -0,0 +1,365 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PluginProtocolVersion IS THE VERSION OF THE JSON-OVER-STDIO PROTOCOL SPOKEN WITH PLUGINS.
+const PluginProtocolVersion = 1
+
+// PluginPrefix IS THE EXECUTABLE NAME PREFIX OF PLUGINS, E.G. preflight-docker.
+const PluginPrefix = "preflight-"
+
+// DefaultPluginDir IS SEARCHED FOR PLUGINS BEFORE PATH, RELATIVE TO THE PROJECT ROOT.
+const DefaultPluginDir = ".preflight/plugins"
+
+// pluginDescribeTimeout LIMITS THE describe CALL MADE WHILE DISCOVERING PLUGINS.
+const pluginDescribeTimeout = 10 * time.Second
+
+// COMMANDS OF THE PLUGIN PROTOCOL.
+const (
+	PluginDescribe = "describe"
+	PluginCheck    = "check"
+	PluginFix      = "fix"
+	PluginList     = "list"
+)
+
+// PluginRequest IS WRITTEN AS JSON TO THE STANDARD INPUT OF A PLUGIN.
+type PluginRequest struct {
+	ProtocolVersion int    `json:"protocolVersion"`
+	Command         string `json:"command"`
+	ProjectDir      string `json:"projectDir"`
+	Force           bool   `json:"force,omitempty"`
+}
+
+// PluginResponse IS READ AS JSON FROM THE STANDARD OUTPUT OF A PLUGIN, ONLY THE FIELDS OF THE COMMAND ARE SET.
+type PluginResponse struct {
+	ProtocolVersion int    `json:"protocolVersion"`
+	Error           string `json:"error,omitempty"`
+
+	// describe.
+	Name        string   `json:"name,omitempty"`
+	Version     string   `json:"version,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Commands    []string `json:"commands,omitempty"`
+	DependsOn   []string `json:"dependsOn,omitempty"`
+
+	// check AND fix.
+	Findings []core.Finding `json:"findings,omitempty"`
+
+	// fix.
+	Message string `json:"message,omitempty"`
+
+	// list.
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+// Plugin IS A DISCOVERED preflight-<name> EXECUTABLE.
+type Plugin struct {
+	Name string
+	Path string
+}
+
+// DiscoverPlugins FINDS preflight-<name> EXECUTABLES IN THE GIVEN DIRECTORIES FOLLOWED BY PATH.
+// THE FIRST EXECUTABLE FOUND FOR A NAME WINS, PLUGINS ARE RETURNED SORTED BY NAME.
+func DiscoverPlugins(dirs ...string) []Plugin {
+	found := make(map[string]Plugin)
+	searchDirs := append(append([]string{}, dirs...), filepath.SplitList(utils.Getenv("PATH"))...)
+
+	for _, dir := range searchDirs {
+		matches, err := utils.Glob(filepath.Join(dir, PluginPrefix+"*"))
+
+		if err != nil {
+			continue
+		}
+
+		for _, match := range matches {
+			info, err := utils.Stat(match)
+
+			if err != nil {
+				continue
+			}
+
+			name, ok := pluginName(fs.FileInfoToDirEntry(info))
+
+			if !ok {
+				continue
+			}
+
+			if _, exists := found[name]; exists {
+				continue
+			}
+
+			path, err := filepath.Abs(match)
+
+			if err != nil {
+				continue
+			}
+
+			found[name] = Plugin{Name: name, Path: path}
+		}
+	}
+
+	plugins := make([]Plugin, 0, len(found))
+
+	for _, plugin := range found {
+		plugins = append(plugins, plugin)
+	}
+
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+
+	return plugins
+}
+
+// pluginName RETURNS THE PLUGIN NAME OF AN EXECUTABLE DIRECTORY ENTRY.
+func pluginName(entry os.DirEntry) (string, bool) {
+	fileName := entry.Name()
+
+	if entry.IsDir() || !strings.HasPrefix(fileName, PluginPrefix) {
+		return "", false
+	}
+
+	if runtime.GOOS == "windows" {
+		if !strings.EqualFold(filepath.Ext(fileName), ".exe") {
+			return "", false
+		}
+
+		fileName = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	} else if info, err := entry.Info(); err != nil || info.Mode()&0o111 == 0 {
+		return "", false
+	}
+
+	name := strings.ToLower(strings.TrimPrefix(fileName, PluginPrefix))
+
+	return name, name != ""
+}
+
+// Call RUNS A PLUGIN COMMAND AND DECODES ITS RESPONSE.
+func (p Plugin) Call(ctx context.Context, command string, force bool) (PluginResponse, error) {
+	return p.call(ctx, PluginRequest{
+		ProtocolVersion: PluginProtocolVersion,
+		Command:         command,
+		ProjectDir:      absProjectDir(ctx),
+		Force:           force,
+	})
+}
+
+// absProjectDir RETURNS THE ABSOLUTE PROJECT DIRECTORY OF THE CONTEXT, PLUGINS MAY RUN FROM ANOTHER DIRECTORY.
+func absProjectDir(ctx context.Context) string {
+	projectDir, _ := filepath.Abs(utils.ProjectDir(ctx))
+	return projectDir
+}
+
+// call SENDS A REQUEST AS-IS, THE COMMAND IS ALSO PASSED AS THE FIRST ARGUMENT FOR CONVENIENCE.
+func (p Plugin) call(ctx context.Context, request PluginRequest) (PluginResponse, error) {
+	var response PluginResponse
+
+	input, err := json.Marshal(request)
+
+	if err != nil {
+		return response, err
+	}
+
+	stdout, stderr, err := utils.RunCommandInput(ctx, input, p.Path, request.Command)
+
+	if err != nil {
+		if message := lastLine(stderr); message != "" {
+			return response, fmt.Errorf("%w: %s", err, message)
+		}
+
+		return response, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(stdout))
+
+	if err := decoder.Decode(&response); err != nil {
+		return response, fmt.Errorf("invalid response to %s: %w", request.Command, err)
+	}
+
+	if decoder.More() {
+		return response, fmt.Errorf("invalid response to %s: unexpected data after the JSON document", request.Command)
+	}
+
+	if response.ProtocolVersion != PluginProtocolVersion {
+		return response, fmt.Errorf("plugin speaks protocol version %d, PreFlight speaks %d", response.ProtocolVersion, PluginProtocolVersion)
+	}
+
+	if response.Error != "" {
+		return response, fmt.Errorf("%s", response.Error)
+	}
+
+	return response, nil
+}
+
+// lastLine RETURNS THE LAST NON-EMPTY LINE OF A COMMAND OUTPUT.
+func lastLine(output []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// PluginModule WRAPS A PLUGIN AS A core.Module, EVERY PLUGIN FAILURE BECOMES A FINDING INSTEAD OF ENDING THE RUN.
+type PluginModule struct {
+	plugin      Plugin
+	description PluginResponse
+	describeErr error
+}
+
+// NewPluginModule DESCRIBES THE PLUGIN, A FAILURE IS REPORTED WHEN THE MODULE IS CHECKED.
+func NewPluginModule(ctx context.Context, plugin Plugin) PluginModule {
+	ctx, cancel := context.WithTimeout(ctx, pluginDescribeTimeout)
+	defer cancel()
+
+	description, err := plugin.Call(ctx, PluginDescribe, false)
+
+	return PluginModule{plugin: plugin, description: description, describeErr: err}
+}
+
+// Name RETURNS THE NAME THE PLUGIN DESCRIBES ITSELF WITH, OR THE NAME OF ITS EXECUTABLE.
+func (p PluginModule) Name() string {
+	if p.describeErr == nil && strings.TrimSpace(p.description.Name) != "" {
+		return strings.TrimSpace(p.description.Name)
+	}
+
+	return p.plugin.Name
+}
+
+// Plugin RETURNS THE WRAPPED EXECUTABLE.
+func (p PluginModule) Plugin() Plugin {
+	return p.plugin
+}
+
+// Description RETURNS THE describe RESPONSE AND ITS ERROR.
+func (p PluginModule) Description() (PluginResponse, error) {
+	return p.description, p.describeErr
+}
+
+// DependsOn RETURNS THE MODULES THE PLUGIN DEPENDS ON.
+func (p PluginModule) DependsOn() []string {
+	return p.description.DependsOn
+}
+
+// Supports REPORTS WHETHER THE PLUGIN IMPLEMENTS A COMMAND.
+func (p PluginModule) Supports(command string) bool {
+	if p.describeErr != nil {
+		return false
+	}
+
+	for _, supported := range p.description.Commands {
+		if strings.EqualFold(supported, command) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckRequirements RUNS THE check COMMAND OF THE PLUGIN.
+func (p PluginModule) CheckRequirements(ctx context.Context) []core.Finding {
+	// CHECK IF CONTEXT IS CANCELED.
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	if p.describeErr != nil {
+		return []core.Finding{p.failure(PluginDescribe, p.describeErr)}
+	}
+
+	if !p.Supports(PluginCheck) {
+		return nil
+	}
+
+	response, err := p.plugin.Call(ctx, PluginCheck, false)
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	if err != nil {
+		return []core.Finding{p.failure(PluginCheck, err)}
+	}
+
+	findings := make([]core.Finding, 0, len(response.Findings))
+
+	for _, finding := range response.Findings {
+		findings = append(findings, p.normalize(finding))
+	}
+
+	return findings
+}
+
+// Fix RUNS THE fix COMMAND OF THE PLUGIN AND RETURNS ITS MESSAGE.
+func (p PluginModule) Fix(ctx context.Context, force bool) (string, error) {
+	if !p.Supports(PluginFix) {
+		return "", fmt.Errorf("plugin %s does not support fix", p.Name())
+	}
+
+	response, err := p.plugin.Call(ctx, PluginFix, force)
+
+	return response.Message, err
+}
+
+// Dependencies RUNS THE list COMMAND OF THE PLUGIN.
+func (p PluginModule) Dependencies(ctx context.Context) ([]string, error) {
+	if !p.Supports(PluginList) {
+		return nil, fmt.Errorf("plugin %s does not support list", p.Name())
+	}
+
+	response, err := p.plugin.Call(ctx, PluginList, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(response.Dependencies)
+
+	return response.Dependencies, nil
+}
+
+// failure CONVERTS A FAILED PLUGIN CALL INTO AN ERROR FINDING.
+func (p PluginModule) failure(command string, err error) core.Finding {
+	return core.Finding{
+		Severity:    core.SeverityError,
+		Module:      p.Name(),
+		RuleID:      core.RulePluginFailed,
+		Subject:     p.plugin.Name,
+		File:        p.plugin.Path,
+		Remediation: fmt.Sprintf("Run `preflight plugin test %s` to verify the plugin", p.plugin.Name),
+		Message:     fmt.Sprintf("Plugin %s%s failed to %s: %v", PluginPrefix, p.plugin.Name, command, err),
+	}
+}
+
+// normalize ATTRIBUTES A PLUGIN FINDING TO THIS MODULE AND FILLS IN MISSING FIELDS.
+func (p PluginModule) normalize(finding core.Finding) core.Finding {
+	finding.Module = p.Name()
+
+	switch finding.Severity {
+	case core.SeverityError, core.SeverityWarning, core.SeveritySuccess:
+	default:
+		finding.Severity = core.SeverityError
+	}
+
+	if finding.RuleID == "" {
+		finding.RuleID = core.RulePluginFinding
+	}
+
+	if finding.Message == "" {
+		finding.Message = finding.RuleID
+	}
+
+	return finding
+}
@@ -0,0 +1,152 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"strings"
+	"time"
+)
+
+// conformanceTimeout LIMITS EVERY CALL MADE BY THE CONFORMANCE HARNESS.
+const conformanceTimeout = 30 * time.Second
+
+// ConformanceResult IS THE OUTCOME OF A SINGLE CONFORMANCE CHECK.
+type ConformanceResult struct {
+	Name   string
+	Passed bool
+	Detail string
+}
+
+// RunPluginConformance VERIFIES THAT A PLUGIN IMPLEMENTS THE PROTOCOL CORRECTLY.
+// fix IS ONLY CALLED WHEN includeFix IS SET, SINCE IT MAY MODIFY THE PROJECT.
+func RunPluginConformance(ctx context.Context, plugin Plugin, includeFix bool) []ConformanceResult {
+	var results []ConformanceResult
+
+	record := func(name string, err error) bool {
+		result := ConformanceResult{Name: name, Passed: err == nil}
+
+		if err != nil {
+			result.Detail = err.Error()
+		}
+
+		results = append(results, result)
+
+		return err == nil
+	}
+
+	// describe IS REQUIRED, NOTHING ELSE CAN BE VERIFIED WITHOUT IT.
+	description, err := callWithTimeout(ctx, plugin, PluginDescribe)
+
+	if !record("describe responds with protocol version "+fmt.Sprint(PluginProtocolVersion), err) {
+		return results
+	}
+
+	record("describe reports a name", func() error {
+		if strings.TrimSpace(description.Name) == "" {
+			return fmt.Errorf("name is empty")
+		}
+
+		return nil
+	}())
+
+	record("describe lists only known commands", func() error {
+		if len(description.Commands) == 0 {
+			return fmt.Errorf("commands is empty")
+		}
+
+		for _, command := range description.Commands {
+			switch command {
+			case PluginCheck, PluginFix, PluginList:
+			default:
+				return fmt.Errorf("unknown command '%s'", command)
+			}
+		}
+
+		return nil
+	}())
+
+	module := PluginModule{plugin: plugin, description: description}
+
+	if module.Supports(PluginCheck) {
+		response, err := callWithTimeout(ctx, plugin, PluginCheck)
+
+		if record("check responds within "+conformanceTimeout.String(), err) {
+			record("check reports valid findings", validateFindings(response.Findings))
+		}
+	}
+
+	if module.Supports(PluginList) {
+		_, err := callWithTimeout(ctx, plugin, PluginList)
+		record("list responds within "+conformanceTimeout.String(), err)
+	}
+
+	if module.Supports(PluginFix) && includeFix {
+		response, err := callWithTimeout(ctx, plugin, PluginFix)
+
+		if record("fix responds within "+conformanceTimeout.String(), err) {
+			record("fix reports valid findings", validateFindings(response.Findings))
+		}
+	}
+
+	// A PLUGIN MUST REJECT WHAT IT DOES NOT UNDERSTAND INSTEAD OF PRETENDING TO SUCCEED.
+	record("rejects unknown commands", expectRejection(ctx, plugin, PluginRequest{
+		ProtocolVersion: PluginProtocolVersion,
+		Command:         "conformance-unknown-command",
+	}))
+
+	record("rejects unsupported protocol versions", expectRejection(ctx, plugin, PluginRequest{
+		ProtocolVersion: PluginProtocolVersion + 1000,
+		Command:         PluginDescribe,
+	}))
+
+	return results
+}
+
+// callWithTimeout CALLS A PLUGIN COMMAND WITHIN conformanceTimeout.
+func callWithTimeout(ctx context.Context, plugin Plugin, command string) (PluginResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, conformanceTimeout)
+	defer cancel()
+
+	return plugin.Call(ctx, command, false)
+}
+
+// expectRejection SENDS AN INVALID REQUEST, WHICH MUST FAIL WITH A NON-ZERO EXIT OR AN ERROR RESPONSE.
+func expectRejection(ctx context.Context, plugin Plugin, request PluginRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, conformanceTimeout)
+	defer cancel()
+
+	request.ProjectDir = absProjectDir(ctx)
+
+	_, err := plugin.call(ctx, request)
+
+	switch {
+	case ctx.Err() != nil:
+		return fmt.Errorf("no response within %s", conformanceTimeout)
+	case err == nil:
+		return fmt.Errorf("the request was accepted")
+	default:
+		return nil
+	}
+}
+
+// validateFindings VERIFIES THAT EVERY FINDING HAS A KNOWN SEVERITY, A RULE ID AND A MESSAGE.
+func validateFindings(findings []core.Finding) error {
+	for i, finding := range findings {
+		switch finding.Severity {
+		case core.SeverityError, core.SeverityWarning, core.SeveritySuccess:
+		default:
+			return fmt.Errorf("finding %d has unknown severity '%s'", i, finding.Severity)
+		}
+
+		if finding.RuleID == "" {
+			return fmt.Errorf("finding %d has no ruleId", i)
+		}
+
+		if finding.Message == "" {
+			return fmt.Errorf("finding %d has no message", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package modules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// conformingPlugin ANSWERS EVERY COMMAND IT DESCRIBES AND REJECTS UNKNOWN COMMANDS AND PROTOCOL VERSIONS.
+const conformingPlugin = `request=$(cat)
+
+case "$request" in
+*'"protocolVersion":1,'*) ;;
+*) echo "unsupported protocol version" >&2; exit 2 ;;
+esac
+
+case "$1" in
+describe) echo '{"protocolVersion":1,"name":"fixture","commands":["check","list"]}' ;;
+check) echo '{"protocolVersion":1,"findings":[{"severity":"success","ruleId":"fixture/ok","message":"All good."}]}' ;;
+list) echo '{"protocolVersion":1}' ;;
+*) echo "unknown command $1" >&2; exit 2 ;;
+esac
+`
+
+// writePlugin WRITES A SHELL SCRIPT PLUGIN WITH THE GIVEN BODY TO A TEMPORARY DIRECTORY.
+func writePlugin(t *testing.T, body string) Plugin {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("fixture plugins are shell scripts")
+	}
+
+	path := filepath.Join(t.TempDir(), PluginPrefix+"fixture")
+
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0o755); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	return Plugin{Name: "fixture", Path: path}
+}
+
+func TestRunPluginConformance(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		failed []string
+		detail string
+	}{
+		{
+			name: "conforming plugin",
+			body: conformingPlugin,
+		},
+		{
+			name:   "crashing plugin",
+			body:   "echo 'segmentation fault' >&2\nexit 139\n",
+			failed: []string{"describe responds with protocol version 1"},
+			detail: "segmentation fault",
+		},
+		{
+			name:   "malformed JSON",
+			body:   "cat >/dev/null\necho '{\"protocolVersion\":1,'\n",
+			failed: []string{"describe responds with protocol version 1"},
+			detail: "invalid response to describe",
+		},
+		{
+			name:   "trailing data",
+			body:   "cat >/dev/null\necho '{\"protocolVersion\":1} {}'\n",
+			failed: []string{"describe responds with protocol version 1"},
+			detail: "unexpected data after the JSON document",
+		},
+		{
+			name:   "finding without rule ID",
+			body:   strings.Replace(conformingPlugin, `"ruleId":"fixture/ok",`, "", 1),
+			failed: []string{"check reports valid findings"},
+			detail: "finding 0 has no ruleId",
+		},
+		{
+			name:   "accepts unknown commands",
+			body:   strings.Replace(conformingPlugin, `*) echo "unknown command $1" >&2; exit 2 ;;`, `*) echo '{"protocolVersion":1}' ;;`, 1),
+			failed: []string{"rejects unknown commands"},
+			detail: "the request was accepted",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := RunPluginConformance(context.Background(), writePlugin(t, test.body), false)
+
+			if len(results) == 0 {
+				t.Fatal("no conformance results")
+			}
+
+			var failed []string
+			var details []string
+
+			for _, result := range results {
+				if !result.Passed {
+					failed = append(failed, result.Name)
+					details = append(details, result.Detail)
+				}
+			}
+
+			if strings.Join(failed, "\n") != strings.Join(test.failed, "\n") {
+				t.Fatalf("failed checks = %q, want %q", failed, test.failed)
+			}
+
+			if test.detail != "" && !strings.Contains(strings.Join(details, "\n"), test.detail) {
+				t.Errorf("details = %q, want them to contain %q", details, test.detail)
+			}
+		})
+	}
+}
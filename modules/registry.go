@@ -0,0 +1,21 @@
+package modules
+
+import "github.com/MineHubs-Studios/PreFlight/core"
+
+// NewRegistry CREATES A REGISTRY WITH ALL BUILT-IN MODULES.
+func NewRegistry() *core.Registry {
+	registry := core.NewRegistry()
+
+	// A NEW REGISTRY HAS NO MODULES YET, SO REGISTERING THE BUILT-IN ONES CANNOT CONFLICT.
+	for name, module := range map[string]core.Module{
+		"php":      PhpModule{},
+		"composer": ComposerModule{},
+		"node":     NodeModule{},
+		"package":  PackageModule{},
+		"go":       GoModule{},
+	} {
+		_ = registry.Register(name, module)
+	}
+
+	return registry
+}
@@ -0,0 +1,39 @@
+package modules
+
+import (
+	"github.com/MineHubs-Studios/PreFlight/config"
+	"slices"
+	"testing"
+)
+
+func TestNewRegistryIsIndependent(t *testing.T) {
+	builtIn := []string{"composer", "go", "node", "package", "php"}
+	first, second := NewRegistry(), NewRegistry()
+
+	custom, err := NewCustomModule(config.CustomModuleConfig{Name: "docker", Binary: "docker"}, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := first.Register("", custom); err != nil {
+		t.Fatalf("Register() = %v", err)
+	}
+
+	// EVERY REGISTRY HAS ITS OWN MODULES, SO REGISTERING THE SAME MODULE IN ANOTHER ONE NEVER CONFLICTS.
+	if err := second.Register("", custom); err != nil {
+		t.Fatalf("Register() on a second registry = %v", err)
+	}
+
+	if err := second.Register("", custom); err == nil {
+		t.Error("Register() of a duplicate module succeeded")
+	}
+
+	if got := NewRegistry().Names(); !slices.Equal(got, builtIn) {
+		t.Errorf("Names() = %v, want %v", got, builtIn)
+	}
+
+	if got, want := first.Names(), []string{"composer", "docker", "go", "node", "package", "php"}; !slices.Equal(got, want) {
+		t.Errorf("first.Names() = %v, want %v", got, want)
+	}
+}
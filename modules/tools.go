@@ -0,0 +1,133 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/core"
+	"github.com/MineHubs-Studios/PreFlight/utils"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// toolVersionRegex EXTRACTS THE FIRST VERSION NUMBER FROM THE OUTPUT OF A --version COMMAND.
+var toolVersionRegex = regexp.MustCompile(`\d+(?:\.\d+){0,2}`)
+
+// ToolsModule VERIFIES THE EXTRA TOOL VERSION CONSTRAINTS OF THE PROJECT CONFIGURATION.
+type ToolsModule struct {
+	// Constraints MAPS A TOOL NAME TO ITS VERSION CONSTRAINT.
+	Constraints map[string]string
+
+	// File IS THE CONFIGURATION FILE THE CONSTRAINTS WERE READ FROM.
+	File string
+}
+
+func (t ToolsModule) Name() string {
+	return "Tools"
+}
+
+// Requirements LISTS THE CONSTRAINED TOOLS.
+func (t ToolsModule) Requirements(_ context.Context) []string {
+	tools := make([]string, 0, len(t.Constraints))
+
+	for tool := range t.Constraints {
+		tools = append(tools, tool)
+	}
+
+	sort.Strings(tools)
+
+	return tools
+}
+
+// CacheInputs THE FINDINGS ONLY DEPEND ON THE CONSTRAINED TOOLS AND THEIR CONSTRAINTS.
+func (t ToolsModule) CacheInputs(ctx context.Context) core.CacheInputs {
+	tools := t.Requirements(ctx)
+	settings := make([]string, 0, len(tools))
+
+	for _, tool := range tools {
+		settings = append(settings, tool+" "+t.Constraints[tool])
+	}
+
+	return core.CacheInputs{Tools: tools, Settings: settings}
+}
+
+// CheckRequirements VERIFIES THAT EVERY CONSTRAINED TOOL IS INSTALLED IN A MATCHING VERSION.
+func (t ToolsModule) CheckRequirements(ctx context.Context) []core.Finding {
+	// CHECK IF CONTEXT IS CANCELED.
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	var findings []core.Finding
+
+	for _, tool := range t.Requirements(ctx) {
+		constraint := strings.TrimSpace(t.Constraints[tool])
+		installedVersion, err := getToolVersion(ctx, tool)
+
+		if ctx.Err() != nil {
+			return findings
+		}
+
+		finding := core.Finding{
+			Severity: core.SeveritySuccess,
+			Module:   t.Name(),
+			RuleID:   core.RuleToolVersion,
+			Subject:  tool,
+			Required: constraint,
+			File:     t.File,
+		}
+
+		if err != nil {
+			finding.Severity = core.SeverityError
+			finding.Remediation = fmt.Sprintf("Install %s %s", tool, constraint)
+			finding.Message = fmt.Sprintf("%s (required %s).", notInstalled(ctx, tool), constraint)
+			findings = append(findings, finding)
+			continue
+		}
+
+		finding.Installed = installedVersion
+		finding.Message = fmt.Sprintf("Installed %s (%s ⟶ required %s).", tool, installedVersion, constraint)
+
+		if isValid, _ := utils.ValidateVersion(installedVersion, constraint); !isValid {
+			finding.Severity = core.SeverityError
+			finding.Remediation = fmt.Sprintf("Install a %s version matching %s", tool, constraint)
+		}
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// getToolVersion RETRIEVES THE INSTALLED VERSION OF A TOOL, REUSING THE PARSERS OF THE BUILT-IN MODULES.
+func getToolVersion(ctx context.Context, tool string) (string, error) {
+	switch strings.ToLower(tool) {
+	case "php":
+		phpVersion, _, _, err := getPhpVersion(ctx)
+		return phpVersion, err
+	case "composer":
+		return GetComposerVersion(ctx)
+	case "node":
+		return getNodeVersion(ctx)
+	case "go":
+		return getGoVersion(ctx)
+	}
+
+	if env := core.EnvironmentFrom(ctx); env != nil {
+		return env.ToolVersion(tool)
+	}
+
+	output, err := utils.RunCommand(ctx, tool, "--version")
+
+	if err != nil {
+		return "", err
+	}
+
+	version := toolVersionRegex.FindString(string(output))
+
+	if version == "" {
+		return "", fmt.Errorf("could not parse the %s version from: %s", tool, strings.TrimSpace(string(output)))
+	}
+
+	return version, nil
+}
@@ -1,8 +1,8 @@
 package main
 
 import (
-	"PreFlight/cmd"
 	"fmt"
+	"github.com/MineHubs-Studios/PreFlight/cmd"
 	"os"
 )
 
@@ -1,6 +1,8 @@
 package utils
 
-import "os"
+import (
+	"path/filepath"
+)
 
 // PackageManager REPRESENTS A DETECTED PACKAGE MANAGER.
 type PackageManager struct {
@@ -14,37 +16,41 @@ type PackageManager struct {
 	LockFile string
 }
 
-// DetectPackageManager IDENTIFIES WHICH PACKAGE MANAGER SHOULD BE USED.
-func DetectPackageManager(packageType string) PackageManager {
-	switch packageType {
-	case "package":
-		if _, err := os.Stat("bun.lock"); err == nil {
-			return PackageManager{Name: "Bun", Command: "bun", LockFile: "bun.lock"}
-		}
-
-		if _, err := os.Stat("pnpm-lock.yaml"); err == nil {
-			return PackageManager{Name: "PNPM", Command: "pnpm", LockFile: "pnpm-lock.yaml"}
-		}
+// lockFilePackageManagers LISTS THE PACKAGE MANAGERS OF EACH PACKAGE TYPE BY THEIR LOCK FILE, IN ORDER OF PRECEDENCE.
+var lockFilePackageManagers = map[string][]PackageManager{
+	"package": {
+		{Name: "Bun", Command: "bun", LockFile: "bun.lock"},
+		{Name: "PNPM", Command: "pnpm", LockFile: "pnpm-lock.yaml"},
+		{Name: "Yarn", Command: "yarn", LockFile: "yarn.lock"},
+		{Name: "NPM", Command: "npm", LockFile: "package-lock.json"},
+	},
+	"composer": {
+		{Name: "Composer", Command: "composer", LockFile: "composer.lock"},
+	},
+	"go": {
+		{Name: "Go Modules", Command: "go", LockFile: "go.mod"},
+	},
+}
 
-		if _, err := os.Stat("yarn.lock"); err == nil {
-			return PackageManager{Name: "Yarn", Command: "yarn", LockFile: "yarn.lock"}
+// DetectPackageManager IDENTIFIES WHICH PACKAGE MANAGER SHOULD BE USED BY THE PROJECT IN projectDir.
+func DetectPackageManager(projectDir, packageType string) PackageManager {
+	for _, pm := range lockFilePackageManagers[packageType] {
+		if _, err := Stat(filepath.Join(projectDir, pm.LockFile)); err == nil {
+			return pm
 		}
+	}
 
-		if _, err := os.Stat("package-lock.json"); err == nil {
-			return PackageManager{Name: "NPM", Command: "npm", LockFile: "package-lock.json"}
-		}
+	// DEFAULT FALLBACK.
+	return PackageManager{Name: "NPM", Command: "npm", LockFile: ""}
+}
 
-	case "composer":
-		if _, err := os.Stat("composer.lock"); err == nil {
-			return PackageManager{Name: "Composer", Command: "composer", LockFile: "composer.lock"}
-		}
+// LockFiles RETURNS THE LOCK FILES DetectPackageManager LOOKS FOR, FOR THE GIVEN PACKAGE TYPE.
+func LockFiles(packageType string) []string {
+	lockFiles := make([]string, 0, len(lockFilePackageManagers[packageType]))
 
-	case "go":
-		if _, err := os.Stat("go.mod"); err == nil {
-			return PackageManager{Name: "Go Modules", Command: "go", LockFile: "go.mod"}
-		}
+	for _, pm := range lockFilePackageManagers[packageType] {
+		lockFiles = append(lockFiles, pm.LockFile)
 	}
 
-	// DEFAULT FALLBACK.
-	return PackageManager{Name: "NPM", Command: "npm", LockFile: ""}
+	return lockFiles
 }
@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// commandTimeoutKey IS THE CONTEXT KEY HOLDING THE PER-COMMAND TIMEOUT.
+type commandTimeoutKey struct{}
+
+// WithCommandTimeout RETURNS A CONTEXT THAT LIMITS EVERY COMMAND STARTED BY RunCommand TO THE GIVEN DURATION.
+func WithCommandTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, commandTimeoutKey{}, timeout)
+}
+
+// CommandTimeout RETURNS THE PER-COMMAND TIMEOUT STORED IN THE CONTEXT, OR ZERO WHEN UNLIMITED.
+func CommandTimeout(ctx context.Context) time.Duration {
+	timeout, _ := ctx.Value(commandTimeoutKey{}).(time.Duration)
+	return timeout
+}
+
+// projectDirKey IS THE CONTEXT KEY HOLDING THE PROJECT DIRECTORY.
+type projectDirKey struct{}
+
+// WithProjectDir RETURNS A CONTEXT WHOSE MODULES READ MANIFESTS FROM, AND RUN COMMANDS IN, THE GIVEN DIRECTORY.
+func WithProjectDir(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, projectDirKey{}, dir)
+}
+
+// ProjectDir RETURNS THE PROJECT DIRECTORY STORED IN THE CONTEXT, AN EMPTY STRING MEANS THE WORKING DIRECTORY.
+func ProjectDir(ctx context.Context) string {
+	dir, _ := ctx.Value(projectDirKey{}).(string)
+	return dir
+}
+
+// ProjectPath RESOLVES A PATH RELATIVE TO THE PROJECT DIRECTORY STORED IN THE CONTEXT.
+func ProjectPath(ctx context.Context, name string) string {
+	return filepath.Join(ProjectDir(ctx), name)
+}
+
+// ParseTimeout PARSES A TIMEOUT GIVEN IN SECONDS OR AS A DURATION, AN EMPTY VALUE MEANS NO TIMEOUT.
+func ParseTimeout(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return 0, nil
+	}
+
+	if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	d, err := time.ParseDuration(value)
+
+	if err != nil {
+		return 0, fmt.Errorf("expected seconds or a duration like 30s, got '%s'", value)
+	}
+
+	return d, nil
+}
+
+// RunCommand RUNS AN EXTERNAL COMMAND AND RETURNS ITS STANDARD OUTPUT.
+// IT RUNS IN THE PROJECT DIRECTORY OF THE CONTEXT, THE WHOLE PROCESS GROUP IS KILLED WHEN THE CONTEXT OR THE PER-COMMAND TIMEOUT EXPIRES.
+// THE COMMAND IS RECORDED IN, OR REPLAYED FROM, THE ACTIVE SESSION WHEN ONE IS SET, SEE SetSession.
+func RunCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	session := activeSession.Load()
+
+	if session != nil && session.replay {
+		stdout, _, err := session.replayCommand(ProjectDir(ctx), name, args)
+		return stdout, err
+	}
+
+	if timeout := CommandTimeout(ctx); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = ProjectDir(ctx)
+	cmd.WaitDelay = time.Second
+	configureProcessGroup(cmd)
+
+	output, err := cmd.Output()
+
+	if ctx.Err() != nil {
+		err = fmt.Errorf("%s timed out: %w", name, ctx.Err())
+	}
+
+	if session != nil {
+		session.recordCommand(cmd.Dir, nil, name, args, output, nil, err)
+	}
+
+	return output, err
+}
+
+// RunCommandInput RUNS AN EXTERNAL COMMAND WITH THE GIVEN STANDARD INPUT AND RETURNS ITS STANDARD OUTPUT AND ERROR.
+// IT APPLIES THE SAME DIRECTORY, TIMEOUTS AND PROCESS GROUP HANDLING AS RunCommand.
+func RunCommandInput(ctx context.Context, input []byte, name string, args ...string) (stdout, stderr []byte, err error) {
+	session := activeSession.Load()
+
+	if session != nil && session.replay {
+		return session.replayCommand(ProjectDir(ctx), name, args)
+	}
+
+	if timeout := CommandTimeout(ctx); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var outBuffer, errBuffer bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = ProjectDir(ctx)
+	cmd.WaitDelay = time.Second
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &outBuffer
+	cmd.Stderr = &errBuffer
+	configureProcessGroup(cmd)
+
+	err = cmd.Run()
+
+	if ctx.Err() != nil {
+		err = fmt.Errorf("%s timed out: %w", name, ctx.Err())
+	}
+
+	if session != nil {
+		session.recordCommand(cmd.Dir, input, name, args, outBuffer.Bytes(), errBuffer.Bytes(), err)
+	}
+
+	return outBuffer.Bytes(), errBuffer.Bytes(), err
+}
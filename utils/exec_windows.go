@@ -0,0 +1,8 @@
+//go:build windows
+
+package utils
+
+import "os/exec"
+
+// configureProcessGroup IS A NO-OP ON WINDOWS, WHERE exec.CommandContext ALREADY KILLS THE PROCESS.
+func configureProcessGroup(_ *exec.Cmd) {}
@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// IgnoreRules MATCHES SLASH-SEPARATED PATHS AGAINST .gitignore-STYLE PATTERNS, THE LAST MATCHING PATTERN WINS.
+type IgnoreRules struct {
+	rules []ignoreRule
+}
+
+// ignoreRule IS A SINGLE COMPILED PATTERN, ONLY MATCHING PATHS BELOW ITS BASE DIRECTORY.
+type ignoreRule struct {
+	base    string
+	pattern *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// AddPatterns ADDS PATTERNS RELATIVE TO base, A SLASH-SEPARATED DIRECTORY WHERE "" IS THE ROOT.
+func (r *IgnoreRules) AddPatterns(base string, patterns []string) {
+	for _, line := range patterns {
+		line = strings.TrimRight(line, " \t\r")
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{base: base}
+
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+
+		// A PATTERN CONTAINING A SLASH IS ANCHORED TO ITS BASE, OTHERWISE IT MATCHES AT ANY DEPTH.
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+
+		if line == "" {
+			continue
+		}
+
+		expression := globToRegexp(line)
+
+		if !anchored {
+			expression = "(?:.*/)?" + expression
+		}
+
+		pattern, err := regexp.Compile("^" + expression + "$")
+
+		if err != nil {
+			continue
+		}
+
+		rule.pattern = pattern
+		r.rules = append(r.rules, rule)
+	}
+}
+
+// AddFile ADDS THE PATTERNS OF A .gitignore FILE IN THE DIRECTORY base, A MISSING FILE ADDS NOTHING.
+func (r *IgnoreRules) AddFile(base, path string) error {
+	file, err := os.Open(path) //nolint:gosec
+
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	var patterns []string
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		patterns = append(patterns, scanner.Text())
+	}
+
+	r.AddPatterns(base, patterns)
+
+	return scanner.Err()
+}
+
+// Ignored REPORTS WHETHER A SLASH-SEPARATED PATH RELATIVE TO THE ROOT IS IGNORED.
+func (r *IgnoreRules) Ignored(path string, isDir bool) bool {
+	ignored := false
+
+	for _, rule := range r.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		relative := path
+
+		if rule.base != "" {
+			if !strings.HasPrefix(path, rule.base+"/") {
+				continue
+			}
+
+			relative = strings.TrimPrefix(path, rule.base+"/")
+		}
+
+		if rule.pattern.MatchString(relative) {
+			ignored = !rule.negate
+		}
+	}
+
+	return ignored
+}
+
+// globToRegexp CONVERTS A .gitignore GLOB INTO A REGULAR EXPRESSION, ** MATCHES ACROSS DIRECTORIES.
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '[':
+			if end := strings.IndexByte(glob[i:], ']'); end > 0 {
+				class := glob[i+1 : i+end]
+
+				if strings.HasPrefix(class, "!") {
+					class = "^" + class[1:]
+				}
+
+				sb.WriteString("[" + class + "]")
+				i += end
+				continue
+			}
+
+			sb.WriteString(regexp.QuoteMeta("["))
+		case c == '\\' && i+1 < len(glob):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return sb.String()
+}
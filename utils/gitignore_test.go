@@ -0,0 +1,199 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestIgnoreRules(t *testing.T) {
+	type match struct {
+		path  string
+		isDir bool
+		want  bool
+	}
+
+	tests := []struct {
+		name     string
+		base     string
+		patterns []string
+		matches  []match
+	}{
+		{
+			name:     "unanchored pattern",
+			patterns: []string{"build"},
+			matches: []match{
+				{path: "build", isDir: true, want: true},
+				{path: "packages/app/build", isDir: true, want: true},
+				{path: "builds", isDir: true},
+			},
+		},
+		{
+			name:     "anchored pattern",
+			patterns: []string{"/build", "docs/generated"},
+			matches: []match{
+				{path: "build", isDir: true, want: true},
+				{path: "packages/build", isDir: true},
+				{path: "docs/generated", isDir: true, want: true},
+				{path: "app/docs/generated", isDir: true},
+			},
+		},
+		{
+			name:     "leading double star",
+			patterns: []string{"**/fixtures"},
+			matches: []match{
+				{path: "fixtures", isDir: true, want: true},
+				{path: "tests/unit/fixtures", isDir: true, want: true},
+				{path: "tests/fixtures-old", isDir: true},
+			},
+		},
+		{
+			name:     "trailing double star",
+			patterns: []string{"examples/**"},
+			matches: []match{
+				{path: "examples/laravel", isDir: true, want: true},
+				{path: "examples/laravel/app", isDir: true, want: true},
+				{path: "examples", isDir: true},
+				{path: "app/examples/laravel", isDir: true},
+			},
+		},
+		{
+			name:     "directory-only pattern",
+			patterns: []string{"cache/"},
+			matches: []match{
+				{path: "cache", isDir: true, want: true},
+				{path: "app/cache", isDir: true, want: true},
+				{path: "cache", isDir: false},
+			},
+		},
+		{
+			name:     "re-inclusion",
+			patterns: []string{"packages/*", "!packages/core"},
+			matches: []match{
+				{path: "packages/legacy", isDir: true, want: true},
+				{path: "packages/core", isDir: true},
+			},
+		},
+		{
+			name:     "last match wins",
+			patterns: []string{"!packages/core", "packages/*"},
+			matches: []match{
+				{path: "packages/core", isDir: true, want: true},
+			},
+		},
+		{
+			name:     "negated character class",
+			patterns: []string{"v[!a-z]"},
+			matches: []match{
+				{path: "v1", isDir: true, want: true},
+				{path: "vx", isDir: true},
+			},
+		},
+		{
+			name:     "escaped characters",
+			patterns: []string{`\#notes`, `\!important`, `file\*`},
+			matches: []match{
+				{path: "#notes", isDir: true, want: true},
+				{path: "!important", isDir: true, want: true},
+				{path: "file*", isDir: true, want: true},
+				{path: "files", isDir: true},
+			},
+		},
+		{
+			name:     "comments and blank lines",
+			patterns: []string{"# build", "", "   "},
+			matches: []match{
+				{path: "# build", isDir: true},
+				{path: "build", isDir: true},
+			},
+		},
+		{
+			name:     "nested base",
+			base:     "apps/web",
+			patterns: []string{"dist", "/public"},
+			matches: []match{
+				{path: "apps/web/dist", isDir: true, want: true},
+				{path: "apps/web/src/dist", isDir: true, want: true},
+				{path: "apps/web/public", isDir: true, want: true},
+				{path: "apps/web/src/public", isDir: true},
+				{path: "apps/api/dist", isDir: true},
+				{path: "dist", isDir: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rules := &IgnoreRules{}
+			rules.AddPatterns(test.base, test.patterns)
+
+			for _, m := range test.matches {
+				if got := rules.Ignored(m.path, m.isDir); got != m.want {
+					t.Errorf("Ignored(%q, %v) = %v, want %v", m.path, m.isDir, got, m.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		glob    string
+		match   []string
+		noMatch []string
+	}{
+		{glob: "*.log", match: []string{"debug.log", ".log"}, noMatch: []string{"logs/debug.log"}},
+		{glob: "file?.txt", match: []string{"file1.txt"}, noMatch: []string{"file10.txt", "file/.txt"}},
+		{glob: "a/**/b", match: []string{"a/b", "a/x/b", "a/x/y/b"}, noMatch: []string{"a/xb"}},
+		{glob: "a/**", match: []string{"a/b", "a/b/c"}, noMatch: []string{"b/a"}},
+		{glob: "[abc].go", match: []string{"a.go"}, noMatch: []string{"d.go"}},
+		{glob: "[!0-9]x", match: []string{"ax"}, noMatch: []string{"1x"}},
+		{glob: "[unclosed", match: []string{"[unclosed"}},
+		{glob: `\[literal\]`, match: []string{"[literal]"}, noMatch: []string{"l"}},
+		{glob: "a+b.(c)", match: []string{"a+b.(c)"}, noMatch: []string{"aab.(c)"}},
+	}
+
+	for _, test := range tests {
+		pattern := regexp.MustCompile("^" + globToRegexp(test.glob) + "$")
+
+		for _, path := range test.match {
+			if !pattern.MatchString(path) {
+				t.Errorf("globToRegexp(%q) = %q does not match %q", test.glob, pattern, path)
+			}
+		}
+
+		for _, path := range test.noMatch {
+			if pattern.MatchString(path) {
+				t.Errorf("globToRegexp(%q) = %q matches %q", test.glob, pattern, path)
+			}
+		}
+	}
+}
+
+func TestIgnoreRulesAddFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".gitignore")
+
+	if err := os.WriteFile(path, []byte("# generated\r\ndist/\r\n!dist/keep\r\n"), 0o644); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	rules := &IgnoreRules{}
+
+	if err := rules.AddFile("web", path); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rules.AddFile("", filepath.Join(dir, "missing", ".gitignore")); err != nil {
+		t.Errorf("AddFile() of a missing file = %v, want nil", err)
+	}
+
+	if !rules.Ignored("web/dist", true) {
+		t.Error("web/dist is not ignored")
+	}
+
+	if rules.Ignored("dist", true) {
+		t.Error("dist outside the base of the .gitignore is ignored")
+	}
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 )
 
@@ -13,8 +14,13 @@ type OutputWriter struct {
 
 // NewOutputWriter CREATES A NEW OutputWriter
 func NewOutputWriter() *OutputWriter {
+	return NewOutputWriterTo(os.Stdout)
+}
+
+// NewOutputWriterTo CREATES A NEW OutputWriter THAT WRITES TO THE GIVEN WRITER.
+func NewOutputWriterTo(w io.Writer) *OutputWriter {
 	return &OutputWriter{
-		w: bufio.NewWriter(os.Stdout),
+		w: bufio.NewWriter(w),
 	}
 }
 
@@ -0,0 +1,415 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// sessionVersion IS INCREMENTED WHENEVER THE FORMAT OF A SESSION CHANGES.
+const sessionVersion = 1
+
+// Session CAPTURES EVERY COMMAND AND FILE ACCESS OF A RUN, OR REPLAYS A CAPTURE INSTEAD OF TOUCHING THE MACHINE.
+type Session struct {
+	Version    int       `json:"version"`
+	RecordedAt time.Time `json:"recordedAt"`
+	OS         string    `json:"os"`
+	Arch       string    `json:"arch"`
+
+	// Args IS THE COMMAND LINE OF THE RECORDED RUN.
+	Args []string `json:"args"`
+
+	// ProjectDir IS THE --dir OF THE RECORDED RUN, ALL RECORDED PATHS ARE BASED ON IT.
+	ProjectDir string `json:"projectDir"`
+
+	Env      map[string]string `json:"env"`
+	Commands []SessionCommand  `json:"commands"`
+	Files    []SessionFile     `json:"files"`
+
+	mu     sync.Mutex
+	replay bool
+
+	// next IS THE INDEX OF THE NEXT REPLAYED ENTRY PER KEY, THE LAST ENTRY IS REUSED ONCE ALL WERE REPLAYED.
+	next map[string]int
+}
+
+// SessionCommand IS A SINGLE RECORDED COMMAND.
+type SessionCommand struct {
+	Dir      string   `json:"dir,omitempty"`
+	Name     string   `json:"name"`
+	Args     []string `json:"args"`
+	Stdin    string   `json:"stdin,omitempty"`
+	Stdout   string   `json:"stdout"`
+	Stderr   string   `json:"stderr,omitempty"`
+	ExitCode int      `json:"exitCode"`
+
+	// Error IS SET WHEN THE COMMAND COULD NOT RUN OR DID NOT FINISH, SUCH AS A MISSING EXECUTABLE.
+	Error string `json:"error,omitempty"`
+}
+
+// SessionFile IS A SINGLE RECORDED FILE ACCESS, Op IS read, stat, readdir OR glob.
+type SessionFile struct {
+	Op         string            `json:"op"`
+	Path       string            `json:"path"`
+	Missing    bool              `json:"missing,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	IsDir      bool              `json:"isDir,omitempty"`
+	Executable bool              `json:"executable,omitempty"`
+	Content    string            `json:"content,omitempty"`
+	Entries    []SessionDirEntry `json:"entries,omitempty"`
+	Matches    []string          `json:"matches,omitempty"`
+}
+
+// SessionDirEntry IS AN ENTRY OF A RECORDED DIRECTORY LISTING.
+type SessionDirEntry struct {
+	Name       string `json:"name"`
+	IsDir      bool   `json:"isDir,omitempty"`
+	Executable bool   `json:"executable,omitempty"`
+}
+
+// activeSession IS THE SESSION EVERY COMMAND AND FILE ACCESS GOES THROUGH, NIL WHEN NEITHER RECORDING NOR REPLAYING.
+var activeSession atomic.Pointer[Session]
+
+// SetSession ACTIVATES A SESSION FOR THE WHOLE PROCESS, NIL DEACTIVATES IT.
+func SetSession(session *Session) {
+	activeSession.Store(session)
+}
+
+// NewSession RETURNS A SESSION THAT RECORDS A RUN OF THE PROJECT IN projectDir.
+func NewSession(projectDir string, args []string) *Session {
+	return &Session{
+		Version:    sessionVersion,
+		RecordedAt: time.Now().UTC(),
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		Args:       args,
+		ProjectDir: projectDir,
+		Env:        make(map[string]string),
+		Commands:   []SessionCommand{},
+		Files:      []SessionFile{},
+	}
+}
+
+// LoadSession READS A SESSION WRITTEN BY --record, READY TO BE REPLAYED.
+func LoadSession(path string) (*Session, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+
+	if err != nil {
+		return nil, err
+	}
+
+	session := &Session{}
+
+	if err := json.Unmarshal(data, session); err != nil {
+		return nil, fmt.Errorf("invalid session %s: %w", path, err)
+	}
+
+	if session.Version != sessionVersion {
+		return nil, fmt.Errorf("unsupported session version %d in %s (supported: %d)", session.Version, path, sessionVersion)
+	}
+
+	session.replay = true
+	session.next = make(map[string]int)
+
+	return session, nil
+}
+
+// Write WRITES THE RECORDED SESSION AS JSON.
+func (s *Session) Write(path string) error {
+	s.mu.Lock()
+	data, err := json.MarshalIndent(s, "", "  ")
+	s.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0o644) //nolint:gosec
+}
+
+// Replaying REPORTS WHETHER THE SESSION REPLAYS A CAPTURE INSTEAD OF RECORDING.
+func (s *Session) Replaying() bool {
+	return s.replay
+}
+
+// recordCommand APPENDS A COMMAND THAT RAN ON THIS MACHINE.
+func (s *Session) recordCommand(dir string, input []byte, name string, args []string, stdout, stderr []byte, err error) {
+	command := SessionCommand{Dir: dir, Name: name, Args: args, Stdin: string(input), Stdout: string(stdout), Stderr: string(stderr)}
+
+	var exitErr *exec.ExitError
+
+	switch {
+	case errors.As(err, &exitErr):
+		command.ExitCode = exitErr.ExitCode()
+
+		if command.Stderr == "" {
+			command.Stderr = string(exitErr.Stderr)
+		}
+	case err != nil:
+		command.ExitCode = -1
+		command.Error = err.Error()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Commands = append(s.Commands, command)
+}
+
+// replayCommand RETURNS THE OUTPUT OF A RECORDED COMMAND, OR AN ERROR WHEN IT WAS NEVER RECORDED.
+func (s *Session) replayCommand(dir, name string, args []string) (stdout, stderr []byte, err error) {
+	key := strings.Join(append([]string{"command", dir, name}, args...), "\x00")
+
+	index := s.replayIndex(key, len(s.Commands), func(i int) bool {
+		command := s.Commands[i]
+		return command.Dir == dir && command.Name == name && slices.Equal(command.Args, args)
+	})
+
+	if index < 0 {
+		return nil, nil, fmt.Errorf("%s %s was not recorded in the session", name, strings.Join(args, " "))
+	}
+
+	command := s.Commands[index]
+
+	switch {
+	case command.Error != "":
+		err = errors.New(command.Error)
+	case command.ExitCode != 0:
+		err = fmt.Errorf("exit status %d", command.ExitCode)
+	}
+
+	return []byte(command.Stdout), []byte(command.Stderr), err
+}
+
+// recordFile APPENDS A FILE ACCESS, A PATH IS RECORDED ONCE PER OPERATION AS FILES DO NOT CHANGE DURING A RUN.
+func (s *Session) recordFile(file SessionFile, err error) {
+	if err != nil {
+		file.Missing = errors.Is(err, fs.ErrNotExist)
+
+		if !file.Missing {
+			file.Error = err.Error()
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, recorded := range s.Files {
+		if recorded.Op == file.Op && recorded.Path == file.Path {
+			return
+		}
+	}
+
+	s.Files = append(s.Files, file)
+}
+
+// replayFile RETURNS A RECORDED FILE ACCESS, FILES THAT WERE NEVER ACCESSED ARE REPORTED AS MISSING.
+func (s *Session) replayFile(op, path string) (SessionFile, error) {
+	index := s.replayIndex(op+"\x00"+path, len(s.Files), func(i int) bool {
+		return s.Files[i].Op == op && s.Files[i].Path == path
+	})
+
+	if index < 0 {
+		return SessionFile{}, &fs.PathError{Op: op, Path: path, Err: fs.ErrNotExist}
+	}
+
+	file := s.Files[index]
+
+	switch {
+	case file.Missing:
+		return file, &fs.PathError{Op: op, Path: path, Err: fs.ErrNotExist}
+	case file.Error != "":
+		return file, &fs.PathError{Op: op, Path: path, Err: errors.New(file.Error)}
+	}
+
+	return file, nil
+}
+
+// replayIndex RETURNS THE NEXT MATCHING ENTRY FOR A KEY, IN RECORDED ORDER, OR -1 WHEN NONE MATCHES.
+func (s *Session) replayIndex(key string, count int, matches func(i int) bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var indexes []int
+
+	for i := range count {
+		if matches(i) {
+			indexes = append(indexes, i)
+		}
+	}
+
+	if len(indexes) == 0 {
+		return -1
+	}
+
+	next := min(s.next[key], len(indexes)-1)
+	s.next[key] = next + 1
+
+	return indexes[next]
+}
+
+// ReadFile READS A FILE, THROUGH THE ACTIVE SESSION WHEN ONE IS SET.
+func ReadFile(path string) ([]byte, error) {
+	session := activeSession.Load()
+
+	if session != nil && session.replay {
+		file, err := session.replayFile("read", path)
+		return []byte(file.Content), err
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec
+
+	if session != nil {
+		session.recordFile(SessionFile{Op: "read", Path: path, Content: string(data)}, err)
+	}
+
+	return data, err
+}
+
+// Stat DESCRIBES A FILE, THROUGH THE ACTIVE SESSION WHEN ONE IS SET.
+func Stat(path string) (fs.FileInfo, error) {
+	session := activeSession.Load()
+
+	if session != nil && session.replay {
+		file, err := session.replayFile("stat", path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return sessionFileInfo{name: filepath.Base(path), dir: file.IsDir, executable: file.Executable}, nil
+	}
+
+	info, err := os.Stat(path)
+
+	if session != nil {
+		file := SessionFile{Op: "stat", Path: path}
+
+		if err == nil {
+			file.IsDir, file.Executable = info.IsDir(), info.Mode()&0o111 != 0
+		}
+
+		session.recordFile(file, err)
+	}
+
+	return info, err
+}
+
+// ReadDir LISTS A DIRECTORY, THROUGH THE ACTIVE SESSION WHEN ONE IS SET.
+func ReadDir(path string) ([]os.DirEntry, error) {
+	session := activeSession.Load()
+
+	if session != nil && session.replay {
+		file, err := session.replayFile("readdir", path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]os.DirEntry, 0, len(file.Entries))
+
+		for _, entry := range file.Entries {
+			entries = append(entries, fs.FileInfoToDirEntry(sessionFileInfo{name: entry.Name, dir: entry.IsDir, executable: entry.Executable}))
+		}
+
+		return entries, nil
+	}
+
+	entries, err := os.ReadDir(path)
+
+	if session != nil {
+		file := SessionFile{Op: "readdir", Path: path}
+
+		for _, entry := range entries {
+			recorded := SessionDirEntry{Name: entry.Name(), IsDir: entry.IsDir()}
+
+			if info, err := entry.Info(); err == nil {
+				recorded.Executable = info.Mode()&0o111 != 0
+			}
+
+			file.Entries = append(file.Entries, recorded)
+		}
+
+		session.recordFile(file, err)
+	}
+
+	return entries, err
+}
+
+// Glob RETURNS THE PATHS MATCHING A PATTERN, THROUGH THE ACTIVE SESSION WHEN ONE IS SET.
+func Glob(pattern string) ([]string, error) {
+	session := activeSession.Load()
+
+	if session != nil && session.replay {
+		file, err := session.replayFile("glob", pattern)
+
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return file.Matches, err
+	}
+
+	matches, err := filepath.Glob(pattern)
+
+	if session != nil {
+		session.recordFile(SessionFile{Op: "glob", Path: pattern, Matches: matches}, err)
+	}
+
+	return matches, err
+}
+
+// Getenv READS AN ENVIRONMENT VARIABLE, THROUGH THE ACTIVE SESSION WHEN ONE IS SET.
+func Getenv(key string) string {
+	session := activeSession.Load()
+
+	if session == nil {
+		return os.Getenv(key)
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	if session.replay {
+		return session.Env[key]
+	}
+
+	value := os.Getenv(key)
+	session.Env[key] = value
+
+	return value
+}
+
+// sessionFileInfo DESCRIBES A REPLAYED FILE, ONLY ITS NAME, WHETHER IT IS A DIRECTORY AND WHETHER IT IS EXECUTABLE ARE RECORDED.
+type sessionFileInfo struct {
+	name       string
+	dir        bool
+	executable bool
+}
+
+func (i sessionFileInfo) Name() string       { return i.name }
+func (i sessionFileInfo) Size() int64        { return 0 }
+func (i sessionFileInfo) ModTime() time.Time { return time.Time{} }
+func (i sessionFileInfo) IsDir() bool        { return i.dir }
+func (i sessionFileInfo) Sys() any           { return nil }
+
+func (i sessionFileInfo) Mode() fs.FileMode {
+	switch {
+	case i.dir:
+		return fs.ModeDir | 0o755
+	case i.executable:
+		return 0o755
+	default:
+		return 0o644
+	}
+}
@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func TestSessionRoundTrip(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fixture commands are shell scripts")
+	}
+
+	dir := t.TempDir()
+	bin := t.TempDir()
+
+	// counter PRINTS HOW OFTEN IT RAN, SO EVERY RUN OF THE SAME COMMAND HAS A DIFFERENT OUTPUT.
+	counter := "#!/bin/sh\ncount=0\n[ -f count ] && read -r count < count\ncount=$((count + 1))\necho $count > count\necho $count\n"
+
+	if err := os.WriteFile(filepath.Join(bin, "counter"), []byte(counter), 0o755); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(bin, "failing"), []byte("#!/bin/sh\necho broken >&2\nexit 3\n"), 0o755); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "composer.json"), []byte(`{"require":{}}`), 0o644); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	t.Setenv("PATH", bin)
+	t.Setenv("PREFLIGHT_SESSION_TEST", "recorded")
+	t.Cleanup(func() { SetSession(nil) })
+
+	ctx := WithProjectDir(context.Background(), dir)
+	manifest := filepath.Join(dir, "composer.json")
+	pattern := filepath.Join(dir, "*.json")
+
+	// run EXERCISES EVERY SEAM OF A SESSION AND RETURNS WHAT IT OBSERVED.
+	run := func() []string {
+		var observed []string
+
+		for range 2 {
+			output, err := RunCommand(ctx, "counter")
+			observed = append(observed, string(output), errorText(err))
+		}
+
+		_, stderr, err := RunCommandInput(ctx, []byte("input"), "failing", "--flag")
+		observed = append(observed, string(stderr), errorText(err))
+
+		data, err := ReadFile(manifest)
+		observed = append(observed, string(data), errorText(err))
+
+		info, err := Stat(dir)
+		observed = append(observed, errorText(err))
+
+		if err == nil {
+			observed = append(observed, info.Name(), info.Mode().String())
+		}
+
+		// A MISSING FILE IS REPLAYED AS MISSING, THE WORDING OF THE ERROR IS PLATFORM SPECIFIC.
+		if _, err = Stat(filepath.Join(dir, "package.json")); errors.Is(err, fs.ErrNotExist) {
+			observed = append(observed, "missing")
+		}
+
+		matches, err := Glob(pattern)
+		observed = append(append(observed, matches...), errorText(err))
+
+		return append(observed, Getenv("PREFLIGHT_SESSION_TEST"))
+	}
+
+	session := NewSession(dir, []string{"check"})
+	SetSession(session)
+	recorded := run()
+	SetSession(nil)
+
+	if recorded[0] != "1\n" || recorded[2] != "2\n" {
+		t.Fatalf("recorded counter outputs %q and %q, want 1 and 2", recorded[0], recorded[2])
+	}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	if err := session.Write(path); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, err := LoadSession(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !replayed.Replaying() {
+		t.Fatal("a loaded session does not replay")
+	}
+
+	// NOTHING OF THE MACHINE MAY BE USED DURING THE REPLAY.
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv("PREFLIGHT_SESSION_TEST", "changed")
+
+	if err := os.Remove(manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	SetSession(replayed)
+
+	if got := run(); !slices.Equal(got, recorded) {
+		t.Errorf("replay = %q, want %q", got, recorded)
+	}
+
+	// ONCE EVERY RUN OF A COMMAND WAS REPLAYED, ITS LAST RUN IS REUSED.
+	if output, _ := RunCommand(ctx, "counter"); string(output) != "2\n" {
+		t.Errorf("extra replay of counter = %q, want 2", output)
+	}
+
+	if _, err := RunCommand(ctx, "counter", "--unrecorded"); err == nil {
+		t.Error("replay of an unrecorded command succeeded")
+	}
+
+	if _, err := ReadFile(filepath.Join(dir, "unrecorded.json")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("replay of an unrecorded file = %v, want it to be missing", err)
+	}
+}
+
+func TestLoadSessionVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	if err := os.WriteFile(path, []byte(`{"version":2}`), 0o644); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	if _, err := LoadSession(path); err == nil {
+		t.Error("LoadSession() of an unsupported version succeeded")
+	}
+}
+
+// errorText RETURNS THE MESSAGE OF AN ERROR, OR NOTHING WITHOUT ONE.
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,14 @@
+package utils
+
+import "os"
+
+// IsTerminal REPORTS WHETHER THE FILE IS CONNECTED TO A TERMINAL.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,155 @@
+//go:build linux
+
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// watchMask SELECTS THE INOTIFY EVENTS THAT CHANGE THE CONTENT OR EXISTENCE OF A FILE.
+const watchMask = syscall.IN_CLOSE_WRITE | syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO | syscall.IN_ATTRIB
+
+// Watcher REPORTS CHANGES OF FILES AND DIRECTORIES DIRECTLY BELOW A ROOT DIRECTORY, USING INOTIFY.
+// A WATCHED DIRECTORY, SUCH AS node_modules, IS REPORTED WHEN AN ENTRY DIRECTLY INSIDE IT CHANGES.
+type Watcher struct {
+	file    *os.File
+	fd      int
+	root    string
+	names   map[string]struct{}
+	dirs    map[int32]string
+	pending []string
+}
+
+// NewWatcher WATCHES THE GIVEN NAMES, RELATIVE TO root, NAMES THAT DO NOT EXIST YET ARE PICKED UP ONCE CREATED.
+func NewWatcher(root string, names []string) (*Watcher, error) {
+	if root == "" {
+		root = "."
+	}
+
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// A NON-BLOCKING DESCRIPTOR USES THE RUNTIME POLLER, SO Close UNBLOCKS A PENDING Next.
+	w := &Watcher{
+		file:  os.NewFile(uintptr(fd), "inotify"),
+		fd:    fd,
+		root:  root,
+		names: make(map[string]struct{}, len(names)),
+		dirs:  make(map[int32]string),
+	}
+
+	if err := w.addWatch(""); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+
+	for _, name := range names {
+		w.names[name] = struct{}{}
+		w.watchDir(name)
+	}
+
+	return w, nil
+}
+
+// Next BLOCKS UNTIL ONE OF THE WATCHED NAMES CHANGES AND RETURNS IT, IT FAILS ONCE THE WATCHER IS CLOSED.
+func (w *Watcher) Next() (string, error) {
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+
+	for len(w.pending) == 0 {
+		n, err := w.file.Read(buf)
+
+		if err != nil {
+			return "", err
+		}
+
+		w.parseEvents(buf[:n])
+	}
+
+	name := w.pending[0]
+	w.pending = w.pending[1:]
+
+	return name, nil
+}
+
+// Close STOPS WATCHING.
+func (w *Watcher) Close() error {
+	return w.file.Close()
+}
+
+// parseEvents QUEUES THE WATCHED NAMES AFFECTED BY A BUFFER OF INOTIFY EVENTS.
+func (w *Watcher) parseEvents(buf []byte) {
+	for len(buf) >= syscall.SizeofInotifyEvent {
+		wd := int32(binary.NativeEndian.Uint32(buf[0:4]))
+		mask := binary.NativeEndian.Uint32(buf[4:8])
+		length := binary.NativeEndian.Uint32(buf[12:16])
+		end := syscall.SizeofInotifyEvent + int(length)
+
+		if end > len(buf) {
+			return
+		}
+
+		name := string(bytes.TrimRight(buf[syscall.SizeofInotifyEvent:end], "\x00"))
+		buf = buf[end:]
+
+		switch {
+		case mask&syscall.IN_Q_OVERFLOW != 0:
+			// EVENTS WERE LOST, SO EVERY NAME MAY HAVE CHANGED.
+			for watched := range w.names {
+				w.pending = append(w.pending, watched)
+			}
+		case mask&syscall.IN_IGNORED != 0:
+			delete(w.dirs, wd)
+		default:
+			dir, ok := w.dirs[wd]
+
+			if !ok {
+				continue
+			}
+
+			// A CHANGE INSIDE A WATCHED DIRECTORY IS REPORTED AS A CHANGE OF THE DIRECTORY ITSELF.
+			if dir != "" {
+				w.pending = append(w.pending, dir)
+				continue
+			}
+
+			if _, watched := w.names[name]; !watched {
+				continue
+			}
+
+			if mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0 {
+				w.watchDir(name)
+			}
+
+			w.pending = append(w.pending, name)
+		}
+	}
+}
+
+// watchDir ADDS A WATCH FOR A NAME THAT IS A DIRECTORY, OTHER NAMES ARE COVERED BY THE WATCH OF THE ROOT.
+func (w *Watcher) watchDir(name string) {
+	if info, err := os.Stat(filepath.Join(w.root, name)); err == nil && info.IsDir() {
+		_ = w.addWatch(name)
+	}
+}
+
+// addWatch WATCHES THE DIRECTORY name BELOW THE ROOT, "" IS THE ROOT ITSELF.
+func (w *Watcher) addWatch(name string) error {
+	path := filepath.Join(w.root, name)
+	wd, err := syscall.InotifyAddWatch(w.fd, path, watchMask)
+
+	if err != nil {
+		return fmt.Errorf("unable to watch %s: %w", path, err)
+	}
+
+	w.dirs[int32(wd)] = name
+
+	return nil
+}
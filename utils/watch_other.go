@@ -0,0 +1,23 @@
+//go:build !linux
+
+package utils
+
+import "errors"
+
+// Watcher IS ONLY AVAILABLE ON LINUX, WHERE IT IS BACKED BY INOTIFY.
+type Watcher struct{}
+
+// NewWatcher FAILS ON PLATFORMS WITHOUT INOTIFY.
+func NewWatcher(_ string, _ []string) (*Watcher, error) {
+	return nil, errors.New("watch mode is only supported on Linux")
+}
+
+// Next FAILS ON PLATFORMS WITHOUT INOTIFY.
+func (w *Watcher) Next() (string, error) {
+	return "", errors.New("watch mode is only supported on Linux")
+}
+
+// Close IS A NO-OP ON PLATFORMS WITHOUT INOTIFY.
+func (w *Watcher) Close() error {
+	return nil
+}